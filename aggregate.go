@@ -0,0 +1,169 @@
+package maps
+
+import "sync"
+
+// Number is a constraint for numeric types
+type Number interface {
+	Integer | ~float32 | ~float64
+}
+
+// Aggregate is a value maintained incrementally from the events of an Observable
+type Aggregate[K comparable, V any, A any] struct {
+	mu     sync.Mutex
+	value  A
+	apply  func(A, Event[K, V]) A
+	obs    []func(new, old A)
+	cancel func()
+}
+
+// NewAggregate creates an *Aggregate[K, V, A] starting from init, which calls apply for every entry in o, and every
+// later Event
+func NewAggregate[K comparable, V any, A any](o *Observable[K, V], init A, apply func(A, Event[K, V]) A) *Aggregate[K, V, A] {
+	a := &Aggregate[K, V, A]{value: init, apply: apply}
+	a.mu.Lock()
+	snapshot, cancel := o.SnapshotAndSubscribe(BatchObserverFunc[K, V](a.observe))
+	for k, v := range snapshot {
+		a.value = apply(a.value, Event[K, V]{Kind: EventCreate, Key: k, New: v})
+	}
+	a.cancel = cancel
+	a.mu.Unlock()
+	return a
+}
+
+func (a *Aggregate[K, V, A]) observe(events []Event[K, V]) {
+	a.mu.Lock()
+	old := a.value
+	for _, e := range events {
+		a.value = a.apply(a.value, e)
+	}
+	new, obs := a.value, a.obs
+	a.mu.Unlock()
+	for _, f := range obs {
+		f(new, old)
+	}
+}
+
+// Get returns the current value
+func (a *Aggregate[K, V, A]) Get() A {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.value
+}
+
+// Observe adds a func which is called every time the value is recalculated
+func (a *Aggregate[K, V, A]) Observe(f func(new, old A)) {
+	a.mu.Lock()
+	a.obs = append(a.obs[:len(a.obs):len(a.obs)], f)
+	a.mu.Unlock()
+}
+
+// Close stops updating the value
+func (a *Aggregate[K, V, A]) Close() { a.cancel() }
+
+// SizeOf creates an *Aggregate which counts the entries of o
+func SizeOf[K comparable, V any](o *Observable[K, V]) *Aggregate[K, V, int] {
+	return NewAggregate(o, 0, func(n int, e Event[K, V]) int {
+		switch {
+		case e.Kind == EventCreate:
+			return n + 1
+		case e.Kind.IsDelete():
+			return n - 1
+		}
+		return n
+	})
+}
+
+// SumOf creates an *Aggregate which sums the values of o
+func SumOf[K comparable, V Number](o *Observable[K, V]) *Aggregate[K, V, V] {
+	return NewAggregate(o, 0, func(sum V, e Event[K, V]) V {
+		switch {
+		case e.Kind == EventCreate:
+			return sum + e.New
+		case e.Kind.IsDelete():
+			return sum - e.Old
+		}
+		return sum + e.New - e.Old
+	})
+}
+
+// Counter is a count of entries in each category, maintained incrementally from the events of an Observable
+type Counter[K comparable, V any, C comparable] struct {
+	mu       sync.Mutex
+	counts   map[C]int
+	category func(K, V) C
+	obs      []func(category C, new, old int)
+	cancel   func()
+}
+
+// CountOf creates a *Counter which counts the entries of o in each category
+func CountOf[K comparable, V any, C comparable](o *Observable[K, V], category func(K, V) C) *Counter[K, V, C] {
+	c := &Counter[K, V, C]{counts: make(map[C]int), category: category}
+	c.mu.Lock()
+	snapshot, cancel := o.SnapshotAndSubscribe(BatchObserverFunc[K, V](c.observe))
+	for k, v := range snapshot {
+		c.counts[category(k, v)]++
+	}
+	c.cancel = cancel
+	c.mu.Unlock()
+	return c
+}
+
+func (c *Counter[K, V, C]) observe(events []Event[K, V]) {
+	before := make(map[C]int)
+	c.mu.Lock()
+	for _, e := range events {
+		if e.Kind != EventCreate {
+			c.add(before, c.category(e.Key, e.Old), -1)
+		}
+		if !e.Kind.IsDelete() {
+			c.add(before, c.category(e.Key, e.New), 1)
+		}
+	}
+	changed := make(map[C]int, len(before))
+	for category, old := range before {
+		if n := c.counts[category]; n != old {
+			changed[category] = n
+		}
+	}
+	obs := c.obs
+	c.mu.Unlock()
+	for category, n := range changed {
+		for _, f := range obs {
+			f(category, n, before[category])
+		}
+	}
+}
+
+// add changes the count for a category, and records its count before the first change in before
+func (c *Counter[K, V, C]) add(before map[C]int, category C, n int) {
+	if _, ok := before[category]; !ok {
+		before[category] = c.counts[category]
+	}
+	if c.counts[category] += n; c.counts[category] == 0 {
+		delete(c.counts, category)
+	}
+}
+
+// Get returns the count for a category
+func (c *Counter[K, V, C]) Get(category C) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[category]
+}
+
+// Counts returns a copy of the count for every category
+func (c *Counter[K, V, C]) Counts() map[C]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return Clone(c.counts)
+}
+
+// Observe adds a func which is called every time the count for a category changes
+func (c *Counter[K, V, C]) Observe(f func(category C, new, old int)) {
+	c.mu.Lock()
+	c.obs = append(c.obs[:len(c.obs):len(c.obs)], f)
+	c.mu.Unlock()
+}
+
+// Close stops updating the counts
+func (c *Counter[K, V, C]) Close() { c.cancel() }
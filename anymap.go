@@ -0,0 +1,178 @@
+package maps
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// AnyMap is a map of decoded values, such as from encoding/json, with typed getters for dot-separated paths
+//
+// Getters return false when the path is missing, or the value has another type. Numbers of any type are accepted
+// where the value is exact
+type AnyMap map[string]any
+
+// Get returns the value at a dot-separated path, see GetPath
+func (m AnyMap) Get(path string) (any, bool) { return GetPath(m, path) }
+
+// GetString returns the string at a path
+func (m AnyMap) GetString(path string) (string, bool) { return anyString(m, path, false) }
+
+// GetInt returns the integer at a path
+func (m AnyMap) GetInt(path string) (int, bool) { return anyInt(m, path, false) }
+
+// GetFloat returns the number at a path
+func (m AnyMap) GetFloat(path string) (float64, bool) { return anyFloat(m, path, false) }
+
+// GetBool returns the bool at a path
+func (m AnyMap) GetBool(path string) (bool, bool) { return anyBool(m, path, false) }
+
+// GetTime returns the time.Time at a path
+func (m AnyMap) GetTime(path string) (time.Time, bool) { return anyTime(m, path, false) }
+
+// Lenient returns the LenientAnyMap for this AnyMap
+func (m AnyMap) Lenient() LenientAnyMap { return LenientAnyMap(m) }
+
+// LenientAnyMap is an AnyMap with getters which convert between types
+//
+// Strings are parsed as numbers, bools, and RFC 3339 times; numbers and bools are formatted as strings; and numbers
+// are read as Unix seconds for times
+type LenientAnyMap map[string]any
+
+// Get returns the value at a dot-separated path, see GetPath
+func (m LenientAnyMap) Get(path string) (any, bool) { return GetPath(m, path) }
+
+// GetString returns the value at a path as a string
+func (m LenientAnyMap) GetString(path string) (string, bool) { return anyString(m, path, true) }
+
+// GetInt returns the value at a path as an integer
+func (m LenientAnyMap) GetInt(path string) (int, bool) { return anyInt(m, path, true) }
+
+// GetFloat returns the value at a path as a number
+func (m LenientAnyMap) GetFloat(path string) (float64, bool) { return anyFloat(m, path, true) }
+
+// GetBool returns the value at a path as a bool
+func (m LenientAnyMap) GetBool(path string) (bool, bool) { return anyBool(m, path, true) }
+
+// GetTime returns the value at a path as a time.Time
+func (m LenientAnyMap) GetTime(path string) (time.Time, bool) { return anyTime(m, path, true) }
+
+func anyString(m map[string]any, path string, lenient bool) (string, bool) {
+	v, _ := GetPath(m, path)
+	if s, ok := v.(string); ok {
+		return s, true
+	} else if !lenient {
+		return "", false
+	}
+	switch v := v.(type) {
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	if f, ok := anyNumber(v); ok {
+		return strconv.FormatFloat(f, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// maxExactFloat is the largest magnitude below which every integer is exact as a float64
+const maxExactFloat = 1 << 53
+
+func anyInt(m map[string]any, path string, lenient bool) (int, bool) {
+	v, _ := GetPath(m, path)
+	var text string
+	switch v := v.(type) {
+	case json.Number:
+		text = v.String()
+	case string:
+		if !lenient {
+			return 0, false
+		}
+		text = v
+	}
+	if text != "" {
+		if i, err := strconv.ParseInt(text, 10, 0); err == nil {
+			return int(i), true
+		} else if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return 0, false
+		}
+	} else if rv := reflect.ValueOf(v); rv.CanInt() {
+		i := rv.Int()
+		return int(i), int64(int(i)) == i
+	} else if rv.CanUint() {
+		u := rv.Uint()
+		return int(u), u <= math.MaxInt
+	}
+	// floats are only exact integers below maxExactFloat, so larger values may have been rounded
+	f, ok := anyFloat(m, path, lenient)
+	if !ok || f != math.Trunc(f) || f <= -maxExactFloat || f >= maxExactFloat {
+		return 0, false
+	}
+	return int(f), true
+}
+
+func anyFloat(m map[string]any, path string, lenient bool) (float64, bool) {
+	v, _ := GetPath(m, path)
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+		return f, err == nil
+	case string:
+		if !lenient {
+			return 0, false
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return anyNumber(v)
+}
+
+func anyBool(m map[string]any, path string, lenient bool) (bool, bool) {
+	v, _ := GetPath(m, path)
+	switch v := v.(type) {
+	case bool:
+		return v, true
+	case string:
+		if !lenient {
+			return false, false
+		}
+		b, err := strconv.ParseBool(v)
+		return b, err == nil
+	}
+	return false, false
+}
+
+func anyTime(m map[string]any, path string, lenient bool) (time.Time, bool) {
+	v, _ := GetPath(m, path)
+	switch v := v.(type) {
+	case time.Time:
+		return v, true
+	case string:
+		if !lenient {
+			return time.Time{}, false
+		}
+		t, err := time.Parse(time.RFC3339Nano, v)
+		return t, err == nil
+	}
+	if f, ok := anyFloat(m, path, lenient); ok && lenient {
+		sec, frac := math.Modf(f)
+		return time.Unix(int64(sec), int64(frac*1e9)), true
+	}
+	return time.Time{}, false
+}
+
+// anyNumber returns the value of any numeric type as a float64
+func anyNumber(v any) (float64, bool) {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || !isNumeric(rv.Kind()) {
+		return 0, false
+	}
+	f := rv.Convert(reflect.TypeFor[float64]()).Float()
+	if reflect.ValueOf(f).Convert(rv.Type()).Interface() != v {
+		return 0, false
+	}
+	return f, true
+}
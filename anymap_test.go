@@ -0,0 +1,34 @@
+package maps
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGetIntDoesNotRound(t *testing.T) {
+	var m AnyMap
+	dec := json.NewDecoder(strings.NewReader(`{"big": 9007199254740993, "float": 9007199254740993.0, "one": 1e0}`))
+	dec.UseNumber()
+	if err := dec.Decode(&m); err != nil {
+		t.Fatal(err)
+	}
+	if i, ok := m.GetInt("big"); !ok || i != 9007199254740993 {
+		t.Fatalf("big = %d, %v", i, ok)
+	}
+	if i, ok := m.GetInt("float"); ok {
+		t.Fatalf("float = %d, %v", i, ok)
+	}
+	if i, ok := m.GetInt("one"); !ok || i != 1 {
+		t.Fatalf("one = %d, %v", i, ok)
+	}
+	if i, ok := m.Lenient().GetInt("missing"); ok {
+		t.Fatalf("missing = %d, %v", i, ok)
+	}
+	if i, ok := (LenientAnyMap{"s": "9007199254740993"}).GetInt("s"); !ok || i != 9007199254740993 {
+		t.Fatalf("s = %d, %v", i, ok)
+	}
+	if i, ok := (AnyMap{"u": uint64(1 << 63)}).GetInt("u"); ok {
+		t.Fatalf("u = %d, %v", i, ok)
+	}
+}
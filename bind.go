@@ -0,0 +1,90 @@
+package maps
+
+import "sync"
+
+// Bind keeps 2 Observables synchronized in both directions, until unbind is called
+//
+// Entries in a are copied into b, then changes to either are converted and applied to the other, from a separate
+// goroutine for each direction. Changes applied by the binding are not applied back to their source, and changes
+// rejected by a BeforeSetFunc or CheckedObserver are skipped
+func Bind[K comparable, A any, B any](a *Observable[K, A], b *Observable[K, B], convert func(A) B, reverse func(B) A) (unbind func()) {
+	ab, ba := newBinding(b, convert), newBinding(a, reverse)
+	a.sync.rw.RLock()
+	for k, v := range a.sync.data {
+		ab.queue.push(Event[K, A]{Kind: EventCreate, Key: k, New: v})
+	}
+	cancelA := a.subscribe(0, BatchObserverFunc[K, A](func(events []Event[K, A]) { ba.forward(events, ab) }))
+	a.sync.rw.RUnlock()
+	cancelB := b.Subscribe(BatchObserverFunc[K, B](func(events []Event[K, B]) { ab.forward(events, ba) }))
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() { defer wg.Done(); ab.run(done) }()
+	go func() { defer wg.Done(); ba.run(done) }()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancelA()
+			cancelB()
+			close(done)
+			wg.Wait()
+		})
+	}
+}
+
+// binding applies converted events to dst, and records the sequence numbers of its own changes
+type binding[K comparable, S any, D any] struct {
+	dst      *Observable[K, D]
+	convert  func(S) D
+	queue    *queue[Event[K, S]]
+	mu       sync.Mutex
+	from, to uint64
+}
+
+func newBinding[K comparable, S any, D any](dst *Observable[K, D], convert func(S) D) *binding[K, S, D] {
+	return &binding[K, S, D]{dst: dst, convert: convert, queue: newQueue[Event[K, S]]()}
+}
+
+// forward queues events to next, except events caused by this binding
+func (bi *binding[K, S, D]) forward(events []Event[K, D], next *binding[K, D, S]) {
+	bi.mu.Lock()
+	from, to := bi.from, bi.to
+	bi.mu.Unlock()
+	for _, e := range events {
+		if e.Seq < from || e.Seq > to {
+			next.queue.push(e)
+		}
+	}
+}
+
+// apply writes converted events to dst, skipping changes rejected by dst, and records the sequence numbers of the
+// changes made before observers are notified
+func (bi *binding[K, S, D]) apply(events []Event[K, S]) {
+	o := bi.dst
+	o.sync.rw.Lock()
+	defer o.unlock()
+	from := o.seq + 1
+	for _, e := range events {
+		if e.Kind.IsDelete() {
+			o.delete(e.Key)
+		} else {
+			o.set(e.Key, bi.convert(e.New))
+		}
+	}
+	bi.mu.Lock()
+	bi.from, bi.to = from, o.seq
+	bi.mu.Unlock()
+}
+
+func (bi *binding[K, S, D]) run(done <-chan struct{}) {
+	for {
+		if events := bi.queue.take(); len(events) > 0 {
+			bi.apply(events)
+		}
+		select {
+		case <-bi.queue.signal:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package maps
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestBindDoesNotLoop(t *testing.T) {
+	a := NewObservable[string, int]()
+	b := NewObservable[string, string]()
+	ra, rb := NewRecorder[string, int](), NewRecorder[string, string]()
+	a.Observe(ra)
+	b.Observe(rb)
+	unbind := Bind(a, b, strconv.Itoa, func(s string) int { n, _ := strconv.Atoi(s); return n })
+	defer unbind()
+
+	a.Set("x", 1)
+	if e, ok := rb.AwaitEvent(time.Second); !ok || e.Key != "x" || e.New != "1" {
+		t.Fatalf("b event = %v, %v", e, ok)
+	}
+	b.Set("y", "2")
+	if _, ok := ra.AwaitEvent(time.Second); !ok {
+		t.Fatal("a did not see its own change")
+	}
+	if e, ok := ra.AwaitEvent(time.Second); !ok || e.Key != "y" || e.New != 2 {
+		t.Fatalf("a event = %v, %v", e, ok)
+	}
+	rb.AwaitEvent(time.Second)
+
+	if e, ok := ra.AwaitEvent(50 * time.Millisecond); ok {
+		t.Fatalf("a echoed %v", e)
+	}
+	if e, ok := rb.AwaitEvent(50 * time.Millisecond); ok {
+		t.Fatalf("b echoed %v", e)
+	}
+	if ra.Len() != 2 || rb.Len() != 2 {
+		t.Fatalf("a events = %v, b events = %v", ra.Events(), rb.Events())
+	}
+}
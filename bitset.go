@@ -0,0 +1,154 @@
+package maps
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// Integer is a constraint for integer types
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// BitSet is a Set of non-negative integers, stored as a bitmap
+//
+// Memory use is proportional to the greatest value. Negative values are never present
+type BitSet[T Integer] struct {
+	words []uint64
+}
+
+// NewBitSet creates a *BitSet[T] with items
+func NewBitSet[T Integer](items ...T) *BitSet[T] {
+	s := &BitSet[T]{}
+	for _, t := range items {
+		s.Add(t)
+	}
+	return s
+}
+
+// Has checks value is in BitSet
+func (s *BitSet[T]) Has(t T) bool {
+	if t < 0 || uint64(t)/64 >= uint64(len(s.words)) {
+		return false
+	}
+	return s.words[uint64(t)/64]&(1<<(uint64(t)%64)) != 0
+}
+
+// Add stores a value, and panics if it is negative
+func (s *BitSet[T]) Add(t T) {
+	if t < 0 {
+		panic("maps: BitSet value must not be negative")
+	}
+	i := uint64(t) / 64
+	if i >= uint64(len(s.words)) {
+		s.words = append(s.words, make([]uint64, i+1-uint64(len(s.words)))...)
+	}
+	s.words[i] |= 1 << (uint64(t) % 64)
+}
+
+// Remove deletes a value
+func (s *BitSet[T]) Remove(t T) {
+	if t >= 0 && uint64(t)/64 < uint64(len(s.words)) {
+		s.words[uint64(t)/64] &^= 1 << (uint64(t) % 64)
+	}
+}
+
+// Delete deletes items
+func (s *BitSet[T]) Delete(items ...T) {
+	for _, t := range items {
+		s.Remove(t)
+	}
+}
+
+// Size returns the number of items
+func (s *BitSet[T]) Size() int {
+	n := 0
+	for _, w := range s.words {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// Slice returns the items as []T, in ascending order
+func (s *BitSet[T]) Slice() []T {
+	slice := make([]T, 0, s.Size())
+	s.Each(func(t T) { slice = append(slice, t) })
+	return slice
+}
+
+// Each calls a function once for every value, in ascending order
+func (s *BitSet[T]) Each(f func(v T)) {
+	for t := range s.All() {
+		f(t)
+	}
+}
+
+// All returns an iterator over the values, in ascending order
+func (s *BitSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, w := range s.words {
+			for w != 0 {
+				b := bits.TrailingZeros64(w)
+				if !yield(T(i*64 + b)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the BitSet
+func (s *BitSet[T]) Clone() *BitSet[T] {
+	return &BitSet[T]{words: append([]uint64(nil), s.words...)}
+}
+
+// Union returns a new BitSet with every value in either BitSet
+func (s *BitSet[T]) Union(other *BitSet[T]) *BitSet[T] {
+	r := s.Clone()
+	r.UnionWith(other)
+	return r
+}
+
+// Intersect returns a new BitSet with every value in both BitSets
+func (s *BitSet[T]) Intersect(other *BitSet[T]) *BitSet[T] {
+	r := s.Clone()
+	r.IntersectWith(other)
+	return r
+}
+
+// Difference returns a new BitSet with every value in this BitSet and not in other
+func (s *BitSet[T]) Difference(other *BitSet[T]) *BitSet[T] {
+	r := s.Clone()
+	r.SubtractWith(other)
+	return r
+}
+
+// UnionWith adds every value in other
+func (s *BitSet[T]) UnionWith(other *BitSet[T]) {
+	if len(other.words) > len(s.words) {
+		s.words = append(s.words, make([]uint64, len(other.words)-len(s.words))...)
+	}
+	for i, w := range other.words {
+		s.words[i] |= w
+	}
+}
+
+// IntersectWith deletes every value not in other
+func (s *BitSet[T]) IntersectWith(other *BitSet[T]) {
+	for i := range s.words {
+		if i < len(other.words) {
+			s.words[i] &= other.words[i]
+		} else {
+			s.words[i] = 0
+		}
+	}
+}
+
+// SubtractWith deletes every value in other
+func (s *BitSet[T]) SubtractWith(other *BitSet[T]) {
+	for i := 0; i < len(s.words) && i < len(other.words); i++ {
+		s.words[i] &^= other.words[i]
+	}
+}
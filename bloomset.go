@@ -0,0 +1,99 @@
+package maps
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+)
+
+// BloomSet is a probabilistic Set, which may report values are present when they are not, but never the reverse
+//
+// A BloomSet uses a fixed number of bits, chosen from the expected number of values and the false positive rate.
+// Values are hashed by their %#v format, so equal values which format differently, such as floating point 0 and -0,
+// are not found
+type BloomSet[T comparable] struct {
+	bits   []uint64
+	m, k   uint64
+	n      int
+	s1, s2 maphash.Seed
+}
+
+// NewBloomSet creates an empty *BloomSet[T] sized for n values with false positive rate p
+//
+// NewBloomSet panics if n is less than 1, or p is not between 0 and 1
+func NewBloomSet[T comparable](n int, p float64) *BloomSet[T] {
+	if n < 1 {
+		panic("maps: BloomSet size must be at least 1")
+	} else if !(p > 0 && p < 1) {
+		panic("maps: BloomSet false positive rate must be between 0 and 1")
+	}
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	m = max(m, 64)
+	k := uint64(math.Round(float64(m) / float64(n) * math.Ln2))
+	k = max(k, 1)
+	return &BloomSet[T]{
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+		s1:   maphash.MakeSeed(),
+		s2:   maphash.MakeSeed(),
+	}
+}
+
+// NewBloomSetFromSet creates a *BloomSet[T] with every value in a Set, with false positive rate p
+//
+// An empty Set is sized for 1 value. NewBloomSetFromSet panics if p is not between 0 and 1
+func NewBloomSetFromSet[T comparable](s Set[T], p float64) *BloomSet[T] {
+	b := NewBloomSet[T](max(len(s), 1), p)
+	for v := range s {
+		b.Add(v)
+	}
+	return b
+}
+
+func (b *BloomSet[T]) each(t T, f func(bit uint64) bool) bool {
+	key, ok := any(t).(string)
+	if !ok {
+		key = fmt.Sprintf("%#v", t)
+	}
+	h1, h2 := maphash.String(b.s1, key), maphash.String(b.s2, key)|1
+	for i := uint64(0); i < b.k; i++ {
+		if !f((h1 + i*h2) % b.m) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add stores a value
+func (b *BloomSet[T]) Add(t T) {
+	b.each(t, func(bit uint64) bool {
+		b.bits[bit/64] |= 1 << (bit % 64)
+		return true
+	})
+	b.n++
+}
+
+// Has checks value may be in BloomSet
+func (b *BloomSet[T]) Has(t T) bool {
+	return b.each(t, func(bit uint64) bool {
+		return b.bits[bit/64]&(1<<(bit%64)) != 0
+	})
+}
+
+// Added returns the number of calls to Add
+func (b *BloomSet[T]) Added() int { return b.n }
+
+// Promote returns a Set with the candidates which may be in the BloomSet
+//
+// The result is approximate, it may contain false positives. It only becomes exact after checking it against the
+// source of the values, such as with Set.Filter
+func (b *BloomSet[T]) Promote(candidates ...T) Set[T] {
+	s := make(Set[T])
+	for _, t := range candidates {
+		if b.Has(t) {
+			s[t] = struct{}{}
+		}
+	}
+	return s
+}
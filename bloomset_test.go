@@ -0,0 +1,19 @@
+package maps
+
+import "testing"
+
+func TestBloomSetHasEveryAddedValue(t *testing.T) {
+	type point struct{ x, y int }
+	b := NewBloomSet[point](100, 0.01)
+	for i := range 100 {
+		b.Add(point{i, -i})
+	}
+	for i := range 100 {
+		if !b.Has(point{i, -i}) {
+			t.Fatalf("missing %d", i)
+		}
+	}
+	if s := b.Promote(point{1, -1}); !s.Has(point{1, -1}) {
+		t.Fatalf("Promote = %v", s)
+	}
+}
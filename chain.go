@@ -0,0 +1,47 @@
+package maps
+
+// CoalesceGet returns the value for a key from the first map where it is present, and false if no map has the key
+func CoalesceGet[M ~map[K]V, K comparable, V any](key K, ms ...M) (V, bool) {
+	for _, m := range ms {
+		if v, ok := m[key]; ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// LookupChain is a list of *Sync layers, where earlier layers override later layers, such as overrides before
+// defaults
+type LookupChain[K comparable, V any] []*Sync[K, V]
+
+// NewLookupChain creates a LookupChain[K, V] with layers in order of precedence
+func NewLookupChain[K comparable, V any](layers ...*Sync[K, V]) LookupChain[K, V] {
+	return LookupChain[K, V](layers)
+}
+
+// GetOk returns the value for a key from the first layer where it is present, and false if no layer has the key
+func (c LookupChain[K, V]) GetOk(key K) (V, bool) {
+	for _, s := range c {
+		if v, ok := s.GetOk(key); ok {
+			return v, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Get returns the value for a key from the first layer where it is present
+func (c LookupChain[K, V]) Get(key K) V {
+	v, _ := c.GetOk(key)
+	return v
+}
+
+// Keys returns the keys present in any layer
+func (c LookupChain[K, V]) Keys() []K {
+	keys := make(Set[K])
+	for _, s := range c {
+		keys.AddSlice(s.Keys())
+	}
+	return keys.Slice()
+}
@@ -0,0 +1,58 @@
+package maps
+
+// CheckedObserver is an interface for observing a change before it is committed, which may reject the change
+//
+// If Prepare returns an error, the change is not made, and every CheckedObserver which already prepared the change is
+// rolled back, in reverse order. When a write with many changes, such as Txn, fails, every change already prepared
+// is also rolled back, newest first
+type CheckedObserver[K comparable, V any] interface {
+	Prepare(e Event[K, V]) error
+	Rollback(e Event[K, V])
+}
+
+type checkedObserver[K comparable, V any] struct {
+	id  uint64
+	obs CheckedObserver[K, V]
+}
+
+// ObserveChecked adds a CheckedObserver, which is called for every value set, after BeforeSet hooks, and every key
+// deleted or expired, and returns a func to remove it
+//
+// e.Seq is not yet assigned. Changes made by Undo, Redo, and Restore are not checked. Cancel must not be called from
+// inside the CheckedObserver
+func (o *Observable[K, V]) ObserveChecked(f CheckedObserver[K, V]) (cancel func()) {
+	id := o.newObserverID()
+	o.sync.rw.Lock()
+	o.checked = append(o.checked, checkedObserver[K, V]{id: id, obs: f})
+	o.sync.rw.Unlock()
+	return func() {
+		o.sync.rw.Lock()
+		for i, c := range o.checked {
+			if c.id == id {
+				o.checked = append(o.checked[:i:i], o.checked[i+1:]...)
+				break
+			}
+		}
+		o.sync.rw.Unlock()
+	}
+}
+
+// prepare calls every CheckedObserver, and rolls back if any returns an error
+func (o *Observable[K, V]) prepare(e Event[K, V]) error {
+	for i, c := range o.checked {
+		if err := c.obs.Prepare(e); err != nil {
+			for j := i - 1; j >= 0; j-- {
+				o.checked[j].obs.Rollback(e)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// rollback calls every CheckedObserver to roll back a prepared change, in reverse order
+func (o *Observable[K, V]) rollback(e Event[K, V]) {
+	for i := len(o.checked) - 1; i >= 0; i-- {
+		o.checked[i].obs.Rollback(e)
+	}
+}
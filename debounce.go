@@ -0,0 +1,118 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce is an Observer which coalesces changes to the same key within a window
+//
+// The window starts at the first change to a key, after which the merged change is delivered to the next Observer,
+// from a timer goroutine
+type Debounce[K comparable, V any] struct {
+	next    Observer[K, V]
+	window  time.Duration
+	mu      sync.Mutex
+	pending map[K]*debounced[K, V]
+}
+
+type debounced[K comparable, V any] struct {
+	event Event[K, V]
+	timer *time.Timer
+}
+
+// NewDebounce creates a *Debounce[K, V] which delivers to next
+func NewDebounce[K comparable, V any](window time.Duration, next Observer[K, V]) *Debounce[K, V] {
+	return &Debounce[K, V]{
+		next:    next,
+		window:  window,
+		pending: make(map[K]*debounced[K, V]),
+	}
+}
+
+// Observe implements Observer
+func (d *Debounce[K, V]) Observe(id K, new, old V) {
+	observeSingle(d, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (d *Debounce[K, V]) ObserveBatch(events []Event[K, V]) {
+	d.mu.Lock()
+	for _, e := range events {
+		if p := d.pending[e.Key]; p != nil {
+			p.event = coalesce(p.event, e)
+			continue
+		}
+		key := e.Key
+		d.pending[key] = &debounced[K, V]{
+			event: e,
+			timer: time.AfterFunc(d.window, func() { d.fire(key) }),
+		}
+	}
+	d.mu.Unlock()
+}
+
+func (d *Debounce[K, V]) fire(key K) {
+	d.mu.Lock()
+	p := d.pending[key]
+	delete(d.pending, key)
+	d.mu.Unlock()
+	if p != nil && p.event.Kind != 0 {
+		notify(d.next, []Event[K, V]{p.event})
+	}
+}
+
+// Pending returns the number of keys waiting to be delivered
+func (d *Debounce[K, V]) Pending() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.pending)
+}
+
+// Flush delivers every pending change immediately, in a single batch
+func (d *Debounce[K, V]) Flush() {
+	d.mu.Lock()
+	events := make([]Event[K, V], 0, len(d.pending))
+	for key, p := range d.pending {
+		p.timer.Stop()
+		if p.event.Kind != 0 {
+			events = append(events, p.event)
+		}
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+	if len(events) > 0 {
+		notify(d.next, events)
+	}
+}
+
+// Stop discards every pending change
+func (d *Debounce[K, V]) Stop() {
+	d.mu.Lock()
+	for key, p := range d.pending {
+		p.timer.Stop()
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+}
+
+// coalesce merges 2 consecutive changes to the same key
+//
+// the result has Kind 0 when the changes cancel out
+func coalesce[K comparable, V any](first, last Event[K, V]) Event[K, V] {
+	if first.Kind == 0 {
+		return last
+	}
+	e := last
+	e.Old = first.Old
+	switch {
+	case first.Kind == EventCreate && last.Kind.IsDelete():
+		e.Kind = 0
+	case first.Kind == EventCreate:
+		e.Kind = EventCreate
+	case last.Kind.IsDelete():
+	default:
+		e.Kind = EventUpdate
+	}
+	return e
+}
@@ -0,0 +1,91 @@
+package maps
+
+// View is a read-only Observable, kept up to date from a source
+type View[K comparable, V any] struct {
+	o      *Observable[K, V]
+	cancel func()
+}
+
+// DeriveObservable creates a *View[K2, V2] which is kept up to date with a projection of src
+//
+// transform returns the derived key and value for a source entry, or false to exclude it.
+// Distinct source keys should derive distinct keys
+func DeriveObservable[K comparable, V any, K2 comparable, V2 any](src *Observable[K, V], transform func(K, V) (K2, V2, bool)) *View[K2, V2] {
+	dst := NewObservable[K2, V2]()
+	keys := make(map[K]K2) // guarded by dst write lock
+	apply := func(tx *Tx[K2, V2], e Event[K, V]) {
+		prev, had := keys[e.Key]
+		if e.Kind.IsDelete() {
+			if had {
+				delete(keys, e.Key)
+				tx.Delete(prev)
+			}
+			return
+		}
+		k2, v2, ok := transform(e.Key, e.New)
+		if had && (!ok || prev != k2) {
+			delete(keys, e.Key)
+			tx.Delete(prev)
+		}
+		if ok {
+			keys[e.Key] = k2
+			tx.Set(k2, v2)
+		}
+	}
+	obs := BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		dst.Txn(func(tx *Tx[K2, V2]) error {
+			for _, e := range events {
+				apply(tx, e)
+			}
+			return nil
+		})
+	})
+	var cancel func()
+	dst.Txn(func(tx *Tx[K2, V2]) error {
+		var snapshot map[K]V
+		snapshot, cancel = src.SnapshotAndSubscribe(obs)
+		for k, v := range snapshot {
+			apply(tx, Event[K, V]{Kind: EventCreate, Key: k, New: v})
+		}
+		return nil
+	})
+	return &View[K2, V2]{o: dst, cancel: cancel}
+}
+
+// Close stops updating the View
+func (v *View[K, V]) Close() { v.cancel() }
+
+// Keys returns the keys
+func (v *View[K, V]) Keys() []K { return v.o.Keys() }
+
+// Values returns the values
+func (v *View[K, V]) Values() []V { return v.o.Values() }
+
+// Size returns the number of items
+func (v *View[K, V]) Size() int { return v.o.Size() }
+
+// Get returns the value for a key
+func (v *View[K, V]) Get(key K) V { return v.o.Get(key) }
+
+// GetOk returns the value for a key, and whether the key is present
+func (v *View[K, V]) GetOk(key K) (V, bool) { return v.o.GetOk(key) }
+
+// Each calls a function, once for every value, inside the mutex lock state
+func (v *View[K, V]) Each(f func(K, V)) { v.o.Each(f) }
+
+// Filter uses a test func to filter the map
+func (v *View[K, V]) Filter(f func(K, V) bool) map[K]V { return v.o.Filter(f) }
+
+// Find uses a test func to find the first passing value
+func (v *View[K, V]) Find(f func(K, V) bool) (K, V) { return v.o.Find(f) }
+
+// Observe adds an observer
+func (v *View[K, V]) Observe(f Observer[K, V]) { v.o.Observe(f) }
+
+// Subscribe adds an observer, and returns a func to remove it
+func (v *View[K, V]) Subscribe(f Observer[K, V]) (cancel func()) { return v.o.Subscribe(f) }
+
+// SnapshotAndSubscribe returns a shallow clone of the data, and adds an observer, with no change between
+func (v *View[K, V]) SnapshotAndSubscribe(f Observer[K, V]) (map[K]V, func()) {
+	return v.o.SnapshotAndSubscribe(f)
+}
@@ -0,0 +1,103 @@
+package maps
+
+// Diff returns the keys added, removed, and changed from old to new
+func Diff[M1 ~map[K]V, M2 ~map[K]V, K comparable, V comparable](old M1, new M2) (added, removed, changed Set[K]) {
+	return DiffFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// DiffFunc returns the keys added, removed, and changed from old to new, where eq reports whether values are equal
+func DiffFunc[M1 ~map[K]V, M2 ~map[K]V, K comparable, V any](old M1, new M2, eq func(a, b V) bool) (added, removed, changed Set[K]) {
+	added, removed, changed = make(Set[K]), make(Set[K]), make(Set[K])
+	for k, o := range old {
+		if n, ok := new[k]; !ok {
+			removed[k] = struct{}{}
+		} else if !eq(o, n) {
+			changed[k] = struct{}{}
+		}
+	}
+	for k := range new {
+		if _, ok := old[k]; !ok {
+			added[k] = struct{}{}
+		}
+	}
+	return
+}
+
+// Patch is a set of changes to a map
+//
+// Each key should appear in at most one of Add, Update, and Delete
+type Patch[K comparable, V any] struct {
+	Add    map[K]V
+	Update map[K]V
+	Delete Set[K]
+}
+
+// NewPatch returns the Patch which changes old into new
+func NewPatch[M1 ~map[K]V, M2 ~map[K]V, K comparable, V comparable](old M1, new M2) Patch[K, V] {
+	return NewPatchFunc(old, new, func(a, b V) bool { return a == b })
+}
+
+// NewPatchFunc returns the Patch which changes old into new, where eq reports whether values are equal
+func NewPatchFunc[M1 ~map[K]V, M2 ~map[K]V, K comparable, V any](old M1, new M2, eq func(a, b V) bool) Patch[K, V] {
+	added, removed, changed := DiffFunc(old, new, eq)
+	p := Patch[K, V]{
+		Add:    make(map[K]V, len(added)),
+		Update: make(map[K]V, len(changed)),
+		Delete: removed,
+	}
+	for k := range added {
+		p.Add[k] = new[k]
+	}
+	for k := range changed {
+		p.Update[k] = new[k]
+	}
+	return p
+}
+
+// Len returns the number of changes in a Patch
+func (p Patch[K, V]) Len() int { return len(p.Add) + len(p.Update) + len(p.Delete) }
+
+// ApplyPatch writes the changes in a Patch to a map
+func ApplyPatch[M ~map[K]V, K comparable, V any](m M, p Patch[K, V]) {
+	for k := range p.Delete {
+		delete(m, k)
+	}
+	for k, v := range p.Add {
+		m[k] = v
+	}
+	for k, v := range p.Update {
+		m[k] = v
+	}
+}
+
+// ApplyPatchSync writes the changes in a Patch to a *Sync, inside a single write lock
+func ApplyPatchSync[K comparable, V any](s *Sync[K, V], p Patch[K, V]) {
+	s.rw.Lock()
+	ApplyPatch(s.data, p)
+	s.rw.Unlock()
+}
+
+// ApplyPatchObservable writes the changes in a Patch to an *Observable, inside a single write lock, and notifies
+// observers once with every change
+//
+// If any BeforeSetFunc or CheckedObserver rejects a change, no change is made, and the error is returned
+func ApplyPatchObservable[K comparable, V any](o *Observable[K, V], p Patch[K, V]) error {
+	return o.atomic(func() error { return o.applyPatch(p) })
+}
+
+// applyPatch writes the changes in a Patch, inside the RWMutex write lock state, stopping at the first rejected change
+func (o *Observable[K, V]) applyPatch(p Patch[K, V]) error {
+	for k := range p.Delete {
+		if _, _, err := o.delete(k); err != nil {
+			return err
+		}
+	}
+	for _, m := range []map[K]V{p.Add, p.Update} {
+		for k, v := range m {
+			if err := o.set(k, v); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
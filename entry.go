@@ -0,0 +1,27 @@
+package maps
+
+// Entry is a key/value pair from a map
+type Entry[K comparable, V any] struct {
+	Key   K
+	Value V
+}
+
+// ToEntries returns the entries of a map
+//
+// The order of entries is not specified
+func ToEntries[M ~map[K]V, K comparable, V any](m M) []Entry[K, V] {
+	r := make([]Entry[K, V], 0, len(m))
+	for k, v := range m {
+		r = append(r, Entry[K, V]{Key: k, Value: v})
+	}
+	return r
+}
+
+// FromEntries returns a map with every entry, where later entries overwrite earlier entries
+func FromEntries[S ~[]Entry[K, V], K comparable, V any](entries S) map[K]V {
+	r := make(map[K]V, len(entries))
+	for _, e := range entries {
+		r[e.Key] = e.Value
+	}
+	return r
+}
@@ -0,0 +1,117 @@
+package maps
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventKind is the kind of change described by an Event
+type EventKind uint8
+
+const (
+	// EventCreate is a value set for a key that was not present
+	EventCreate EventKind = iota + 1
+	// EventUpdate is a value set for a key that was present
+	EventUpdate
+	// EventDelete is a key removed
+	EventDelete
+	// EventExpire is a key removed when its TTL passed
+	EventExpire
+)
+
+// String returns the name of the EventKind
+func (k EventKind) String() string {
+	switch k {
+	case EventCreate:
+		return "create"
+	case EventUpdate:
+		return "update"
+	case EventDelete:
+		return "delete"
+	case EventExpire:
+		return "expire"
+	}
+	return "unknown"
+}
+
+// IsDelete returns whether the kind is EventDelete or EventExpire
+func (k EventKind) IsDelete() bool { return k == EventDelete || k == EventExpire }
+
+// MarshalText implements encoding.TextMarshaler
+func (k EventKind) MarshalText() ([]byte, error) {
+	if k < EventCreate || k > EventExpire {
+		return nil, fmt.Errorf("maps: unknown EventKind %d", k)
+	}
+	return []byte(k.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (k *EventKind) UnmarshalText(text []byte) error {
+	for kind := EventCreate; kind <= EventExpire; kind++ {
+		if kind.String() == string(text) {
+			*k = kind
+			return nil
+		}
+	}
+	return fmt.Errorf("maps: unknown EventKind %q", text)
+}
+
+// Event is a single change to an Observable
+//
+// Seq increases by 1 for every Event from the same Observable, and Time is read from its clock
+type Event[K comparable, V any] struct {
+	Seq  uint64
+	Time time.Time
+	Kind EventKind
+	Key  K
+	New  V
+	Old  V
+}
+
+// BatchObserver is an interface for observing changes in batches
+//
+// Observers which also implement BatchObserver receive ObserveBatch instead of Observe, with the Seq and Time of
+// every Event
+type BatchObserver[K comparable, V any] interface {
+	ObserveBatch(events []Event[K, V])
+}
+
+// BatchObserverFunc is a func type that implements Observer and BatchObserver
+type BatchObserverFunc[K comparable, V any] func(events []Event[K, V])
+
+func (f BatchObserverFunc[K, V]) Observe(id K, new, old V) { observeSingle(f, id, new, old) }
+
+func (f BatchObserverFunc[K, V]) ObserveBatch(events []Event[K, V]) { f(events) }
+
+// observeSingle delivers a change passed to Observe, which has no Event, to a BatchObserver
+//
+// The change is delivered as an EventUpdate, since Observe does not say whether a key was created or deleted.
+// Observable, and Middleware, only deliver to a BatchObserver with ObserveBatch, so this is only reached when Observe
+// is called directly
+func observeSingle[K comparable, V any](b BatchObserver[K, V], id K, new, old V) {
+	b.ObserveBatch([]Event[K, V]{{Kind: EventUpdate, Key: id, New: new, Old: old}})
+}
+
+// notify delivers events to an Observer, preferring BatchObserver
+func notify[K comparable, V any](obs Observer[K, V], events []Event[K, V]) {
+	asBatch(obs).ObserveBatch(events)
+}
+
+// asBatch returns an Observer as a BatchObserver, which calls Observe for every Event if it is not already one
+func asBatch[K comparable, V any](obs Observer[K, V]) BatchObserver[K, V] {
+	if b, ok := obs.(BatchObserver[K, V]); ok {
+		return b
+	}
+	return observerBatch[K, V]{obs}
+}
+
+// observerBatch adapts an Observer to BatchObserver
+type observerBatch[K comparable, V any] struct {
+	obs Observer[K, V]
+}
+
+func (b observerBatch[K, V]) ObserveBatch(events []Event[K, V]) {
+	for _, e := range events {
+		b.obs.Observe(e.Key, e.New, e.Old)
+	}
+}
@@ -0,0 +1,109 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// ExpiringSet is a concurrency-safe Set where each value may expire
+//
+// Expired values are treated as absent, and removed by Cleanup
+type ExpiringSet[T comparable] struct {
+	mu    sync.Mutex
+	items map[T]time.Time
+}
+
+// NewExpiringSet creates an empty *ExpiringSet[T]
+func NewExpiringSet[T comparable]() *ExpiringSet[T] {
+	return &ExpiringSet[T]{items: make(map[T]time.Time)}
+}
+
+// Add stores a value, which expires after ttl, or never expires if ttl <= 0
+func (s *ExpiringSet[T]) Add(t T, ttl time.Duration) {
+	var at time.Time
+	if ttl > 0 {
+		at = time.Now().Add(ttl)
+	}
+	s.mu.Lock()
+	s.items[t] = at
+	s.mu.Unlock()
+}
+
+// Has checks value is in ExpiringSet, and not expired
+func (s *ExpiringSet[T]) Has(t T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at, ok := s.items[t]
+	if ok && expired(at, time.Now()) {
+		delete(s.items, t)
+		return false
+	}
+	return ok
+}
+
+// Remove deletes a value
+func (s *ExpiringSet[T]) Remove(t T) {
+	s.mu.Lock()
+	delete(s.items, t)
+	s.mu.Unlock()
+}
+
+// Size returns the number of values which are not expired
+func (s *ExpiringSet[T]) Size() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, now := 0, time.Now()
+	for _, at := range s.items {
+		if !expired(at, now) {
+			n++
+		}
+	}
+	return n
+}
+
+// Slice returns the values which are not expired as []T
+func (s *ExpiringSet[T]) Slice() []T {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	slice, now := make([]T, 0, len(s.items)), time.Now()
+	for t, at := range s.items {
+		if !expired(at, now) {
+			slice = append(slice, t)
+		}
+	}
+	return slice
+}
+
+// Cleanup removes every expired value, and returns the number removed
+func (s *ExpiringSet[T]) Cleanup() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n, now := 0, time.Now()
+	for t, at := range s.items {
+		if expired(at, now) {
+			delete(s.items, t)
+			n++
+		}
+	}
+	return n
+}
+
+// Janitor calls Cleanup every interval from a new goroutine, until stop is called
+func (s *ExpiringSet[T]) Janitor(interval time.Duration) (stop func()) {
+	ticker, done := time.NewTicker(interval), make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.Cleanup()
+			case <-done:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+func expired(at, now time.Time) bool { return !at.IsZero() && !now.Before(at) }
@@ -1,3 +1,3 @@
 module taylz.io/maps
 
-go 1.22
+go 1.23
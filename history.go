@@ -0,0 +1,65 @@
+package maps
+
+import "errors"
+
+// ErrHistoryGap is returned when events requested for replay are no longer kept
+var ErrHistoryGap = errors.New("maps: history does not reach requested sequence")
+
+// history is a ring buffer of the most recent events
+type history[K comparable, V any] struct {
+	ring []Event[K, V]
+	next int
+	size int
+}
+
+func newHistory[K comparable, V any](n int) history[K, V] {
+	return history[K, V]{ring: make([]Event[K, V], n)}
+}
+
+func (h *history[K, V]) push(e Event[K, V]) {
+	if len(h.ring) < 1 {
+		return
+	}
+	h.ring[h.next] = e
+	h.next = (h.next + 1) % len(h.ring)
+	if h.size < len(h.ring) {
+		h.size++
+	}
+}
+
+// pop discards the most recent event
+func (h *history[K, V]) pop() {
+	if h.size < 1 {
+		return
+	}
+	h.next = (h.next - 1 + len(h.ring)) % len(h.ring)
+	h.ring[h.next] = Event[K, V]{}
+	h.size--
+}
+
+// events returns the kept events, oldest first
+func (h *history[K, V]) events() []Event[K, V] {
+	events := make([]Event[K, V], h.size)
+	start := h.next - h.size
+	if start < 0 {
+		start += len(h.ring)
+	}
+	for i := range events {
+		events[i] = h.ring[(start+i)%len(h.ring)]
+	}
+	return events
+}
+
+// since returns the kept events with Seq >= from, given the last seq issued
+func (h *history[K, V]) since(from, last uint64) ([]Event[K, V], error) {
+	if from < 1 {
+		from = 1
+	}
+	if from > last {
+		return nil, nil
+	} else if last-from >= uint64(h.size) {
+		return nil, ErrHistoryGap
+	}
+	events := h.events()
+	return events[len(events)-int(last-from+1):], nil
+}
@@ -0,0 +1,160 @@
+package maps
+
+import "iter"
+
+// All returns an iterator over a snapshot of the entries, which does not hold the lock while iterating
+func (s *Sync[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s.rw.RLock()
+		snapshot := Clone(s.data)
+		s.rw.RUnlock()
+		for k, v := range snapshot {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// AllKeys returns an iterator over a snapshot of the keys, which does not hold the lock while iterating
+func (s *Sync[K, V]) AllKeys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for _, k := range s.Keys() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// AllValues returns an iterator over a snapshot of the values, which does not hold the lock while iterating
+func (s *Sync[K, V]) AllValues() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s.Values() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over a snapshot of the entries, which does not hold the lock while iterating
+func (o *Observable[K, V]) All() iter.Seq2[K, V] { return o.sync.All() }
+
+// AllKeys returns an iterator over a snapshot of the keys, which does not hold the lock while iterating
+func (o *Observable[K, V]) AllKeys() iter.Seq[K] { return o.sync.AllKeys() }
+
+// AllValues returns an iterator over a snapshot of the values, which does not hold the lock while iterating
+func (o *Observable[K, V]) AllValues() iter.Seq[V] { return o.sync.AllValues() }
+
+// All returns an iterator over the values
+func (s Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Product returns an iterator over every pair of values from a and b
+func Product[A comparable, B comparable](a Set[A], b Set[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		for x := range a {
+			for y := range b {
+				if !yield(x, y) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// PowerSet returns an iterator over every subset of a Set, starting with the empty Set
+//
+// Each subset is a new Set, and only one is held at a time
+func PowerSet[T comparable](s Set[T]) iter.Seq[Set[T]] {
+	items := s.Slice()
+	return func(yield func(Set[T]) bool) {
+		in := make([]bool, len(items))
+		for {
+			subset := make(Set[T])
+			for i, ok := range in {
+				if ok {
+					subset[items[i]] = struct{}{}
+				}
+			}
+			if !yield(subset) {
+				return
+			}
+			i := 0
+			for ; i < len(in) && in[i]; i++ {
+				in[i] = false
+			}
+			if i == len(in) {
+				return
+			}
+			in[i] = true
+		}
+	}
+}
+
+// CountBySeq returns a map from each class to the number of values in a sequence in that class
+func CountBySeq[V any, K comparable](seq iter.Seq[V], classify func(V) K) map[K]int {
+	r := make(map[K]int)
+	for v := range seq {
+		r[classify(v)]++
+	}
+	return r
+}
+
+// FromSeq returns a map with every entry of a sequence, where later entries overwrite earlier entries
+func FromSeq[K comparable, V any](seq iter.Seq2[K, V]) map[K]V {
+	r := make(map[K]V)
+	InsertSeq(r, seq)
+	return r
+}
+
+// InsertSeq writes every entry of a sequence to dst
+func InsertSeq[M ~map[K]V, K comparable, V any](dst M, seq iter.Seq2[K, V]) {
+	for k, v := range seq {
+		dst[k] = v
+	}
+}
+
+// ToSeq returns an iterator over the entries of a map
+func ToSeq[M ~map[K]V, K comparable, V any](m M) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range m {
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// InsertSeq writes every entry of a sequence, inside the RWMutex write lock state
+//
+// seq must not use the Sync
+func (s *Sync[K, V]) InsertSeq(seq iter.Seq2[K, V]) {
+	s.rw.Lock()
+	InsertSeq(s.data, seq)
+	s.rw.Unlock()
+}
+
+// InsertSeq writes every entry of a sequence, inside the RWMutex write lock state, and notifies observers once with
+// every change
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject a change, which stops the sequence, in
+// which case no change is kept. seq must not use the Observable
+func (o *Observable[K, V]) InsertSeq(seq iter.Seq2[K, V]) error {
+	return o.atomic(func() error {
+		for k, v := range seq {
+			if err := o.set(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package maps
+
+// Pair is 2 values for the same key, from the maps passed to Join
+type Pair[A any, B any] struct {
+	Left  A
+	Right B
+}
+
+// Join returns a map with every key present in either map, paired with the value from each map
+//
+// When a key is missing from one map, that side of the Pair is the zero value
+func Join[MA ~map[K]A, MB ~map[K]B, K comparable, A any, B any](a MA, b MB) map[K]Pair[A, B] {
+	r := LeftJoin(a, b)
+	for k, v := range b {
+		if _, ok := a[k]; !ok {
+			r[k] = Pair[A, B]{Right: v}
+		}
+	}
+	return r
+}
+
+// LeftJoin returns a map with every key present in a, paired with the value from each map
+//
+// When a key is missing from b, the Right side of the Pair is the zero value
+func LeftJoin[MA ~map[K]A, MB ~map[K]B, K comparable, A any, B any](a MA, b MB) map[K]Pair[A, B] {
+	r := make(map[K]Pair[A, B], len(a))
+	for k, v := range a {
+		r[k] = Pair[A, B]{Left: v, Right: b[k]}
+	}
+	return r
+}
+
+// InnerJoin returns a map with every key present in both maps, paired with the value from each map
+func InnerJoin[MA ~map[K]A, MB ~map[K]B, K comparable, A any, B any](a MA, b MB) map[K]Pair[A, B] {
+	r := make(map[K]Pair[A, B], min(len(a), len(b)))
+	for k, v := range a {
+		if w, ok := b[k]; ok {
+			r[k] = Pair[A, B]{Left: v, Right: w}
+		}
+	}
+	return r
+}
@@ -0,0 +1,84 @@
+package maps
+
+import (
+	"errors"
+	"io"
+	"sync"
+)
+
+// Encoder is an interface for writing values, such as *json.Encoder or *gob.Encoder
+type Encoder interface {
+	Encode(v any) error
+}
+
+// Decoder is an interface for reading values, such as *json.Decoder or *gob.Decoder
+type Decoder interface {
+	Decode(v any) error
+}
+
+// Journal is an Observer which writes every Event to an Encoder
+type Journal[K comparable, V any] struct {
+	mu  sync.Mutex
+	enc Encoder
+	err error
+}
+
+// NewJournal creates a *Journal[K, V] which writes to enc
+func NewJournal[K comparable, V any](enc Encoder) *Journal[K, V] {
+	return &Journal[K, V]{enc: enc}
+}
+
+// Observe implements Observer
+func (j *Journal[K, V]) Observe(id K, new, old V) {
+	observeSingle(j, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (j *Journal[K, V]) ObserveBatch(events []Event[K, V]) {
+	j.mu.Lock()
+	for i := 0; i < len(events) && j.err == nil; i++ {
+		j.err = j.enc.Encode(events[i])
+	}
+	j.mu.Unlock()
+}
+
+// Err returns the first error returned by the Encoder, after which no more events are written
+func (j *Journal[K, V]) Err() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.err
+}
+
+// Restore reads events written by a Journal, and applies them until dec returns io.EOF
+//
+// Restored events do not call BeforeSet hooks, CheckedObservers, or observers, and the sequence continues from the
+// last event. Restored keys lose their TTL, the steps for Undo and Redo are discarded, and restored events are kept
+// for Replay only when they follow the sequence without a gap
+func (o *Observable[K, V]) Restore(dec Decoder) error {
+	o.sync.rw.Lock()
+	defer o.sync.rw.Unlock()
+	for {
+		var e Event[K, V]
+		if err := dec.Decode(&e); errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if e.Kind.IsDelete() {
+			delete(o.sync.data, e.Key)
+		} else {
+			o.sync.data[e.Key] = e.New
+		}
+		o.dropTTL(e.Key)
+		if o.undo != nil {
+			o.undo.done, o.undo.undone = nil, nil
+		}
+		if e.Seq != o.seq+1 {
+			o.history = newHistory[K, V](len(o.history.ring))
+		}
+		if e.Seq > o.seq {
+			o.seq = e.Seq
+			o.history.push(e)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package maps
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MarshalJSON implements json.Marshaler, encoding only the data
+func (o *Observable[K, V]) MarshalJSON() ([]byte, error) {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	return json.Marshal(o.sync.data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, merging entries into the data
+//
+// By default, entries are loaded silently, see SetJSONNotify. Either way, loaded keys lose their TTL, and if any
+// change is rejected, no change is kept
+func (o *Observable[K, V]) UnmarshalJSON(data []byte) error {
+	var m map[K]V
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	return o.atomic(func() error {
+		if o.sync.data == nil {
+			o.sync.data = make(map[K]V, len(m))
+		}
+		if o.now == nil {
+			o.now = time.Now
+		}
+		for k, v := range m {
+			if !o.jsonNotify {
+				o.sync.data[k] = v
+				o.dropTTL(k)
+			} else if err := o.set(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// SetJSONNotify sets whether UnmarshalJSON calls BeforeSet hooks and notifies observers for each entry
+func (o *Observable[K, V]) SetJSONNotify(notify bool) {
+	o.sync.rw.Lock()
+	o.jsonNotify = notify
+	o.sync.rw.Unlock()
+}
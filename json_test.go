@@ -0,0 +1,40 @@
+package maps
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestUnmarshalJSONRevertsOnPanic(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.SetJSONNotify(true)
+	o.BeforeSet(func(e Event[string, int]) error {
+		if e.Key == "b" {
+			panic("boom")
+		}
+		return nil
+	})
+	func() {
+		defer func() { recover() }()
+		json.Unmarshal([]byte(`{"a": 1, "b": 2, "c": 3}`), o)
+	}()
+	if o.Size() != 0 || o.Seq() != 0 {
+		t.Fatalf("keys = %v, seq = %d", o.Keys(), o.Seq())
+	}
+}
+
+func TestUnmarshalJSONStopsTTL(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.SetWithTTL("a", 1, time.Millisecond)
+	if err := json.Unmarshal([]byte(`{"a": 2}`), o); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.TTL("a"); ok {
+		t.Fatal("a kept its TTL")
+	}
+	time.Sleep(10 * time.Millisecond)
+	if o.Get("a") != 2 {
+		t.Fatal("the old TTL expired the loaded value")
+	}
+}
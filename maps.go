@@ -1,5 +1,13 @@
 package maps
 
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrDuplicateKey is returned when 2 entries produce the same key, and there is no way to resolve them
+var ErrDuplicateKey = errors.New("maps: duplicate key")
+
 // Keys returns the keys of a map
 func Keys[M ~map[K]V, K comparable, V any](m M) []K {
 	r := make([]K, 0, len(m))
@@ -18,6 +26,101 @@ func Values[M ~map[K]V, K comparable, V any](m M) []V {
 	return r
 }
 
+// KeySet returns the keys of a map as a Set
+func KeySet[M ~map[K]V, K comparable, V any](m M) Set[K] {
+	r := make(Set[K], len(m))
+	for k := range m {
+		r[k] = struct{}{}
+	}
+	return r
+}
+
+// ValueSet returns the distinct values of a map as a Set
+func ValueSet[M ~map[K]V, K comparable, V comparable](m M) Set[V] {
+	r := make(Set[V])
+	for _, v := range m {
+		r[v] = struct{}{}
+	}
+	return r
+}
+
+// UnionKeys returns the keys present in any map
+func UnionKeys[M ~map[K]V, K comparable, V any](ms ...M) Set[K] {
+	r := make(Set[K])
+	for _, m := range ms {
+		for k := range m {
+			r[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// IntersectKeys returns the keys present in every map
+func IntersectKeys[M ~map[K]V, K comparable, V any](ms ...M) Set[K] {
+	r := make(Set[K])
+	if len(ms) == 0 {
+		return r
+	}
+	smallest := ms[0]
+	for _, m := range ms[1:] {
+		if len(m) < len(smallest) {
+			smallest = m
+		}
+	}
+next:
+	for k := range smallest {
+		for _, m := range ms {
+			if _, ok := m[k]; !ok {
+				continue next
+			}
+		}
+		r[k] = struct{}{}
+	}
+	return r
+}
+
+// DiffKeys returns the keys present in a, which are not present in b
+func DiffKeys[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1 any, V2 any](a M1, b M2) Set[K] {
+	r := make(Set[K])
+	for k := range a {
+		if _, ok := b[k]; !ok {
+			r[k] = struct{}{}
+		}
+	}
+	return r
+}
+
+// UniqueValues returns the distinct values of a map
+//
+// The order of values is not specified
+func UniqueValues[M ~map[K]V, K comparable, V comparable](m M) []V {
+	return UniqueValuesFunc(m, func(v V) V { return v })
+}
+
+// UniqueValuesFunc returns the values of a map with distinct results of keyOf, keeping either value when 2 match
+//
+// The order of values is not specified
+func UniqueValuesFunc[M ~map[K]V, K comparable, V any, C comparable](m M, keyOf func(V) C) []V {
+	seen := make(Set[C])
+	var r []V
+	for _, v := range m {
+		if c := keyOf(v); !seen.Has(c) {
+			seen.Add(c)
+			r = append(r, v)
+		}
+	}
+	return r
+}
+
+// SetToMap returns a map with every value in a Set as a key, with the value fill
+func SetToMap[T comparable, V any](s Set[T], fill V) map[T]V {
+	r := make(map[T]V, len(s))
+	for t := range s {
+		r[t] = fill
+	}
+	return r
+}
+
 // Clone returns a shallow clone of a map
 func Clone[M ~map[K]V, K comparable, V any](m M) M {
 	if m == nil {
@@ -30,6 +133,18 @@ func Clone[M ~map[K]V, K comparable, V any](m M) M {
 	return r
 }
 
+// DeepClone returns a clone of a map, with the result of cloneV for every value
+func DeepClone[M ~map[K]V, K comparable, V any](m M, cloneV func(V) V) M {
+	if m == nil {
+		return nil
+	}
+	r := make(M, len(m))
+	for k, v := range m {
+		r[k] = cloneV(v)
+	}
+	return r
+}
+
 func Each[M ~map[K]V, K comparable, V any](m M, f func(K, V)) {
 	if m == nil {
 		return
@@ -53,6 +168,29 @@ func Filter[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) M {
 	return r
 }
 
+// Partition returns shallow clones of a map, one containing each entry where test returns true and one containing
+// the rest
+func Partition[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) (pass M, fail M) {
+	if m == nil {
+		return nil, nil
+	}
+	pass, fail = make(M), make(M)
+	for k, v := range m {
+		if test(k, v) {
+			pass[k] = v
+		} else {
+			fail[k] = v
+		}
+	}
+	return
+}
+
+// SplitByKeys returns shallow clones of a map, one containing each entry where the key is in keys and one
+// containing the rest
+func SplitByKeys[M ~map[K]V, K comparable, V any](m M, keys Set[K]) (in M, out M) {
+	return Partition(m, func(k K, _ V) bool { return keys.Has(k) })
+}
+
 // Find returns the entry key and value for the first entry where test returns true
 func Find[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) (_k K, _v V) {
 	if m == nil {
@@ -67,6 +205,54 @@ func Find[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) (_k K, _v
 	return
 }
 
+// Every returns whether test returns true for every entry, which is true for an empty map
+func Every[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) bool {
+	for k, v := range m {
+		if !test(k, v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Some returns whether test returns true for any entry
+func Some[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) bool {
+	for k, v := range m {
+		if test(k, v) {
+			return true
+		}
+	}
+	return false
+}
+
+// None returns whether test returns false for every entry
+func None[M ~map[K]V, K comparable, V any](m M, test func(K, V) bool) bool {
+	return !Some(m, test)
+}
+
+// ContainsValue returns whether any entry has the value v
+func ContainsValue[M ~map[K]V, K comparable, V comparable](m M, v V) bool {
+	for _, val := range m {
+		if val == v {
+			return true
+		}
+	}
+	return false
+}
+
+// KeysOfValue returns the keys of every entry with the value v
+//
+// The order of keys is not specified
+func KeysOfValue[M ~map[K]V, K comparable, V comparable](m M, v V) []K {
+	var r []K
+	for k, val := range m {
+		if val == v {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
 // Reduce returns an accumulation of a map using an accumulation func
 func Reduce[M ~map[K]V, K comparable, V any, A any](m M, a A, f func(A, K, V) A) A {
 	if m == nil {
@@ -93,3 +279,181 @@ func DeleteFunc[M ~map[K]V, K comparable, V any](m M, del func(K, V) bool) {
 		}
 	}
 }
+
+// Compact returns a shallow clone of a map without each entry where the value is the zero value
+func Compact[M ~map[K]V, K comparable, V comparable](m M) M {
+	var zero V
+	return CompactFunc(m, func(v V) bool { return v == zero })
+}
+
+// CompactFunc returns a shallow clone of a map without each entry where empty returns true
+func CompactFunc[M ~map[K]V, K comparable, V any](m M, empty func(V) bool) M {
+	return Filter(m, func(_ K, v V) bool { return !empty(v) })
+}
+
+// CompactInPlace deletes from a map where the value is the zero value
+func CompactInPlace[M ~map[K]V, K comparable, V comparable](m M) {
+	var zero V
+	DeleteFunc(m, func(_ K, v V) bool { return v == zero })
+}
+
+// CompactFuncInPlace deletes from a map where empty returns true
+func CompactFuncInPlace[M ~map[K]V, K comparable, V any](m M, empty func(V) bool) {
+	DeleteFunc(m, func(_ K, v V) bool { return empty(v) })
+}
+
+// MapValues returns a map with the same keys, and the result of f for every value
+func MapValues[M ~map[K]V, K comparable, V any, W any](m M, f func(K, V) W) map[K]W {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]W, len(m))
+	for k, v := range m {
+		r[k] = f(k, v)
+	}
+	return r
+}
+
+// FilterMap returns a map with the same keys, and the result of f for every value, without each entry where f
+// returns false
+func FilterMap[M ~map[K]V, K comparable, V any, W any](m M, f func(K, V) (W, bool)) map[K]W {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]W)
+	for k, v := range m {
+		if w, ok := f(k, v); ok {
+			r[k] = w
+		}
+	}
+	return r
+}
+
+// MapKeys returns a map with the result of f as the key for every value
+//
+// When 2 entries produce the same key, resolve returns the value to keep, in map iteration order. If resolve is nil,
+// MapKeys returns an error wrapping ErrDuplicateKey instead
+func MapKeys[M ~map[K]V, K comparable, V any, K2 comparable](m M, f func(K, V) K2, resolve func(key K2, prev, next V) V) (map[K2]V, error) {
+	if m == nil {
+		return nil, nil
+	}
+	r := make(map[K2]V, len(m))
+	for k, v := range m {
+		k2 := f(k, v)
+		if prev, ok := r[k2]; !ok {
+			r[k2] = v
+		} else if resolve != nil {
+			r[k2] = resolve(k2, prev, v)
+		} else {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, k2)
+		}
+	}
+	return r, nil
+}
+
+// MapEntries returns a map with the result of f for every entry
+//
+// When 2 entries produce the same key, either value may be kept
+func MapEntries[M ~map[K]V, K comparable, V any, K2 comparable, V2 any](m M, f func(K, V) (K2, V2)) map[K2]V2 {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K2]V2, len(m))
+	for k, v := range m {
+		k2, v2 := f(k, v)
+		r[k2] = v2
+	}
+	return r
+}
+
+// Invert returns a map with the keys and values swapped
+//
+// When 2 keys have the same value, resolve returns the key to keep, in map iteration order. If resolve is nil, Invert
+// returns an error wrapping ErrDuplicateKey instead
+func Invert[M ~map[K]V, K comparable, V comparable](m M, resolve func(val V, prev, next K) K) (map[V]K, error) {
+	if m == nil {
+		return nil, nil
+	}
+	r := make(map[V]K, len(m))
+	for k, v := range m {
+		if prev, ok := r[v]; !ok {
+			r[v] = k
+		} else if resolve != nil {
+			r[v] = resolve(v, prev, k)
+		} else {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, v)
+		}
+	}
+	return r, nil
+}
+
+// InvertMulti returns a map from each value to every key with that value
+//
+// The order of keys in each slice is not specified
+func InvertMulti[M ~map[K]V, K comparable, V comparable](m M) map[V][]K {
+	if m == nil {
+		return nil
+	}
+	r := make(map[V][]K)
+	for k, v := range m {
+		r[v] = append(r[v], k)
+	}
+	return r
+}
+
+// Merge returns a new map with every entry of each map, where later maps overwrite earlier maps
+func Merge[M ~map[K]V, K comparable, V any](ms ...M) M {
+	n := 0
+	for _, m := range ms {
+		n += len(m)
+	}
+	r := make(M, n)
+	for _, m := range ms {
+		for k, v := range m {
+			r[k] = v
+		}
+	}
+	return r
+}
+
+// MergeFunc writes all key/value pairs in src to dst, where resolve returns the value for keys present in both
+func MergeFunc[M1 ~map[K]V, M2 ~map[K]V, K comparable, V any](dst M1, src M2, resolve func(k K, dstV, srcV V) V) {
+	for k, v := range src {
+		if d, ok := dst[k]; ok {
+			dst[k] = resolve(k, d, v)
+		} else {
+			dst[k] = v
+		}
+	}
+}
+
+// Equal returns whether 2 maps have the same entries
+func Equal[M1 ~map[K]V, M2 ~map[K]V, K comparable, V comparable](a M1, b M2) bool {
+	return EqualFunc(a, b, func(x, y V) bool { return x == y })
+}
+
+// EqualFunc returns whether 2 maps have the same keys, with values that eq reports are equal
+func EqualFunc[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1 any, V2 any](a M1, b M2, eq func(V1, V2) bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v1 := range a {
+		if v2, ok := b[k]; !ok || !eq(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubmap returns whether every entry of sub is present in super, with a value that eq reports is equal
+func IsSubmap[M1 ~map[K]V1, M2 ~map[K]V2, K comparable, V1 any, V2 any](sub M1, super M2, eq func(V1, V2) bool) bool {
+	if len(sub) > len(super) {
+		return false
+	}
+	for k, v1 := range sub {
+		if v2, ok := super[k]; !ok || !eq(v1, v2) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// Memoize returns a func which calls f once for each key, and returns the cached value after
+//
+// The returned func is not safe for concurrent use, see MemoizeSync
+func Memoize[K comparable, V any](f func(K) V) func(K) V {
+	cache := make(map[K]V)
+	return func(key K) V {
+		if v, ok := cache[key]; ok {
+			return v
+		}
+		v := f(key)
+		cache[key] = v
+		return v
+	}
+}
+
+// MemoizeOptions configures MemoizeSync
+type MemoizeOptions struct {
+	// TTL is how long a value is cached, forever by default
+	TTL time.Duration
+	// MaxSize is the most values cached, after which the oldest value is removed, unlimited by default
+	MaxSize int
+}
+
+// MemoizeSync returns a func which calls f for each key, and returns the cached value after, which is safe for
+// concurrent use
+//
+// f is called without holding the lock, so concurrent calls for the same key may call f more than once
+func MemoizeSync[K comparable, V any](f func(K) V, opts MemoizeOptions) func(K) V {
+	type memoized struct {
+		val V
+		at  time.Time
+	}
+	var mu sync.Mutex
+	cache, order := make(map[K]memoized), NewOrderedSet[K]()
+	return func(key K) V {
+		mu.Lock()
+		m, ok := cache[key]
+		if ok && opts.TTL > 0 && time.Since(m.at) >= opts.TTL {
+			delete(cache, key)
+			order.Remove(key)
+			ok = false
+		}
+		mu.Unlock()
+		if ok {
+			return m.val
+		}
+		m = memoized{val: f(key), at: time.Now()}
+		mu.Lock()
+		defer mu.Unlock()
+		order.Remove(key)
+		cache[key] = m
+		order.Add(key)
+		for opts.MaxSize > 0 && order.Size() > opts.MaxSize {
+			for oldest := range order.All() {
+				delete(cache, oldest)
+				order.Remove(oldest)
+				break
+			}
+		}
+		return m.val
+	}
+}
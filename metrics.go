@@ -0,0 +1,101 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// Metrics is an Observer which counts events
+//
+// Metrics.Middleware measures the time taken to deliver events to observers
+type Metrics[K comparable, V any] struct {
+	mu     sync.Mutex
+	snap   MetricsSnapshot
+	keys   Set[K]
+	queues []func() int
+}
+
+// MetricsSnapshot is a copy of the values tracked by Metrics
+type MetricsSnapshot struct {
+	// Sets is the number of values set
+	Sets uint64
+	// Deletes is the number of keys deleted
+	Deletes uint64
+	// Keys is the number of distinct keys changed
+	Keys int
+	// Deliveries is the number of deliveries measured by Middleware
+	Deliveries uint64
+	// Latency is the total time taken by deliveries measured by Middleware
+	Latency time.Duration
+	// MaxLatency is the longest time taken by a delivery measured by Middleware
+	MaxLatency time.Duration
+	// QueueDepth is the total depth of queues added by TrackQueue
+	QueueDepth int
+}
+
+// NewMetrics creates a *Metrics[K, V]
+func NewMetrics[K comparable, V any]() *Metrics[K, V] {
+	return &Metrics[K, V]{keys: NewSet[K]()}
+}
+
+// Observe implements Observer
+func (m *Metrics[K, V]) Observe(id K, new, old V) {
+	observeSingle(m, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (m *Metrics[K, V]) ObserveBatch(events []Event[K, V]) {
+	m.mu.Lock()
+	for _, e := range events {
+		if e.Kind.IsDelete() {
+			m.snap.Deletes++
+		} else {
+			m.snap.Sets++
+		}
+		m.keys.Add(e.Key)
+	}
+	m.mu.Unlock()
+}
+
+// Middleware is a Middleware which measures the time taken by each delivery to next
+func (m *Metrics[K, V]) Middleware(next BatchObserver[K, V]) BatchObserver[K, V] {
+	return BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		start := time.Now()
+		next.ObserveBatch(events)
+		d := time.Since(start)
+		m.mu.Lock()
+		m.snap.Deliveries++
+		m.snap.Latency += d
+		if d > m.snap.MaxLatency {
+			m.snap.MaxLatency = d
+		}
+		m.mu.Unlock()
+	})
+}
+
+// TrackQueue adds a queue depth func, such as Debounce.Pending, to QueueDepth
+func (m *Metrics[K, V]) TrackQueue(depth func() int) {
+	m.mu.Lock()
+	m.queues = append(m.queues, depth)
+	m.mu.Unlock()
+}
+
+// Snapshot returns a copy of the current values
+func (m *Metrics[K, V]) Snapshot() MetricsSnapshot {
+	m.mu.Lock()
+	snap, queues := m.snap, m.queues
+	snap.Keys = len(m.keys)
+	m.mu.Unlock()
+	for _, depth := range queues {
+		snap.QueueDepth += depth()
+	}
+	return snap
+}
+
+// Reset sets every value to zero, and keeps tracked queues
+func (m *Metrics[K, V]) Reset() {
+	m.mu.Lock()
+	m.snap = MetricsSnapshot{}
+	m.keys = NewSet[K]()
+	m.mu.Unlock()
+}
@@ -0,0 +1,29 @@
+package maps
+
+import "sync"
+
+// Mirror copies the data into dst, and replicates every later change into dst, until stop is called
+func (o *Observable[K, V]) Mirror(dst *Sync[K, V]) (stop func()) {
+	return o.MirrorMap(dst.data, &dst.rw)
+}
+
+// MirrorMap copies the data into dst, and replicates every later change into dst, until stop is called
+//
+// dst is only written while holding lock
+func (o *Observable[K, V]) MirrorMap(dst map[K]V, lock sync.Locker) (stop func()) {
+	lock.Lock()
+	snapshot, stop := o.SnapshotAndSubscribe(BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		lock.Lock()
+		for _, e := range events {
+			if e.Kind.IsDelete() {
+				delete(dst, e.Key)
+			} else {
+				dst[e.Key] = e.New
+			}
+		}
+		lock.Unlock()
+	}))
+	Copy(dst, snapshot)
+	lock.Unlock()
+	return stop
+}
@@ -0,0 +1,87 @@
+package maps
+
+import "slices"
+
+// Multiset is a map[T]int, which counts each value
+type Multiset[T comparable] map[T]int
+
+// NewMultiset creates an empty Multiset[T]
+func NewMultiset[T comparable]() Multiset[T] { return make(Multiset[T]) }
+
+// Add adds n of a value
+func (m Multiset[T]) Add(t T, n int) {
+	if n > 0 {
+		m[t] += n
+	}
+}
+
+// Remove removes up to n of a value
+func (m Multiset[T]) Remove(t T, n int) {
+	if m[t] <= n {
+		delete(m, t)
+	} else if n > 0 {
+		m[t] -= n
+	}
+}
+
+// Count returns the count of a value
+func (m Multiset[T]) Count(t T) int { return m[t] }
+
+// Len returns the number of distinct values
+func (m Multiset[T]) Len() int { return len(m) }
+
+// TotalLen returns the sum of every count
+func (m Multiset[T]) TotalLen() int {
+	n := 0
+	for _, c := range m {
+		n += c
+	}
+	return n
+}
+
+// Union returns a new Multiset with the greater count of every value in either Multiset
+func (m Multiset[T]) Union(other Multiset[T]) Multiset[T] {
+	r := make(Multiset[T], len(m))
+	for t, c := range m {
+		r[t] = c
+	}
+	for t, c := range other {
+		if c > r[t] {
+			r[t] = c
+		}
+	}
+	return r
+}
+
+// Intersect returns a new Multiset with the lesser count of every value in both Multisets
+func (m Multiset[T]) Intersect(other Multiset[T]) Multiset[T] {
+	r := make(Multiset[T])
+	for t, c := range m {
+		if oc := other[t]; oc > 0 {
+			r[t] = min(c, oc)
+		}
+	}
+	return r
+}
+
+// MostCommon returns up to n values with the greatest counts, in descending order of count
+//
+// Values with the same count are sorted if T is ordered
+func (m Multiset[T]) MostCommon(n int) []T {
+	items := Keys(m)
+	sortIfOrdered(items)
+	slices.SortStableFunc(items, func(a, b T) int { return m[b] - m[a] })
+	if n < len(items) {
+		items = items[:max(n, 0)]
+	}
+	return items
+}
+
+// Set returns a Set with every value
+func (m Multiset[T]) Set() Set[T] {
+	s := make(Set[T], len(m))
+	for t := range m {
+		s[t] = struct{}{}
+	}
+	return s
+}
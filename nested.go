@@ -0,0 +1,206 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrPathConflict is returned when a nested key is needed where a value which is not a map[string]any is present
+var ErrPathConflict = errors.New("maps: path conflict")
+
+// SliceStrategy is how DeepMerge combines slices
+type SliceStrategy int
+
+const (
+	// SliceReplace replaces the dst slice with the src slice
+	SliceReplace SliceStrategy = iota
+	// SliceAppend appends the src slice to the dst slice
+	SliceAppend
+	// SliceMergeByIndex merges each src item with the dst item at the same index
+	SliceMergeByIndex
+)
+
+// DeepMergeOptions configures DeepMerge
+type DeepMergeOptions struct {
+	// Slices is how []any values are combined, SliceReplace by default
+	Slices SliceStrategy
+}
+
+// DeepMerge writes all key/value pairs in src to dst, merging nested map[string]any values recursively
+//
+// Nested maps and slices in src are copied, so later changes to dst do not change src
+func DeepMerge(dst, src map[string]any, opts DeepMergeOptions) {
+	for k, v := range src {
+		dst[k] = deepMerge(dst[k], v, opts)
+	}
+}
+
+// deepMerge returns the result of merging src with dst
+func deepMerge(dst, src any, opts DeepMergeOptions) any {
+	switch s := src.(type) {
+	case map[string]any:
+		d, ok := dst.(map[string]any)
+		if !ok {
+			d = make(map[string]any, len(s))
+		}
+		DeepMerge(d, s, opts)
+		return d
+	case []any:
+		d, ok := dst.([]any)
+		if !ok {
+			break
+		}
+		switch opts.Slices {
+		case SliceAppend:
+			r := append(d[:len(d):len(d)], s...)
+			for i := len(d); i < len(r); i++ {
+				r[i] = deepClone(r[i])
+			}
+			return r
+		case SliceMergeByIndex:
+			r := make([]any, max(len(d), len(s)))
+			copy(r, d)
+			for i, v := range s {
+				r[i] = deepMerge(r[i], v, opts)
+			}
+			return r
+		}
+	}
+	return deepClone(src)
+}
+
+// Flatten returns a map with the value of every nested map[string]any, with keys joined by sep
+//
+// Empty nested maps are kept as values, so Unflatten restores them
+func Flatten(m map[string]any, sep string) map[string]any {
+	r := make(map[string]any)
+	flatten(r, "", m, sep)
+	return r
+}
+
+func flatten(dst map[string]any, prefix string, m map[string]any, sep string) {
+	for k, v := range m {
+		if prefix != "" {
+			k = prefix + sep + k
+		}
+		if n, ok := v.(map[string]any); ok && len(n) > 0 {
+			flatten(dst, k, n, sep)
+		} else {
+			dst[k] = v
+		}
+	}
+}
+
+// Unflatten returns a map with keys split by sep into nested map[string]any
+//
+// If a key is both a value and a prefix of another key, Unflatten returns an error wrapping ErrPathConflict
+func Unflatten(m map[string]any, sep string) (map[string]any, error) {
+	r := make(map[string]any)
+	// a key sorts after its prefixes, so in reverse order, a conflict is always found at the prefix
+	sorted := Keys(m)
+	slices.Sort(sorted)
+	slices.Reverse(sorted)
+	for _, k := range sorted {
+		v := m[k]
+		keys := strings.Split(k, sep)
+		if _, ok := lookupKeys(r, keys); ok {
+			return nil, fmt.Errorf("%w: %s", ErrPathConflict, k)
+		} else if err := setKeys(r, keys, sep, v); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// lookupKeys returns the value at a sequence of nested keys
+func lookupKeys(m map[string]any, keys []string) (any, bool) {
+	for _, k := range keys[:len(keys)-1] {
+		n, ok := m[k].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		m = n
+	}
+	v, ok := m[keys[len(keys)-1]]
+	return v, ok
+}
+
+// setKeys writes the value at a sequence of nested keys, creating nested maps as needed
+func setKeys(m map[string]any, keys []string, sep string, v any) error {
+	for i, k := range keys[:len(keys)-1] {
+		switch n := m[k].(type) {
+		case map[string]any:
+			m = n
+		case nil:
+			next := make(map[string]any)
+			m[k] = next
+			m = next
+		default:
+			return fmt.Errorf("%w: %s", ErrPathConflict, strings.Join(keys[:i+1], sep))
+		}
+	}
+	m[keys[len(keys)-1]] = v
+	return nil
+}
+
+// GetPath returns the value at a dot-separated path of nested map[string]any, and whether it is present
+func GetPath(m map[string]any, path string) (any, bool) {
+	return lookupKeys(m, strings.Split(path, "."))
+}
+
+// SetPath writes the value at a dot-separated path of nested map[string]any, creating nested maps as needed
+//
+// If the path passes through a value which is not a map[string]any, SetPath returns an error wrapping
+// ErrPathConflict
+func SetPath(m map[string]any, path string, v any) error {
+	return setKeys(m, strings.Split(path, "."), ".", v)
+}
+
+// DeletePath deletes the value at a dot-separated path of nested map[string]any, and returns whether it was present
+//
+// Nested maps left empty are not deleted
+func DeletePath(m map[string]any, path string) bool {
+	keys := strings.Split(path, ".")
+	parent, ok := m, true
+	if len(keys) > 1 {
+		var v any
+		if v, ok = lookupKeys(m, keys[:len(keys)-1]); ok {
+			parent, ok = v.(map[string]any)
+		}
+	}
+	if !ok {
+		return false
+	}
+	last := keys[len(keys)-1]
+	if _, ok = parent[last]; ok {
+		delete(parent, last)
+	}
+	return ok
+}
+
+// DeepCloneAny returns a clone of a map, with every nested map[string]any and []any cloned recursively
+func DeepCloneAny(m map[string]any) map[string]any {
+	if m == nil {
+		return nil
+	}
+	return DeepClone(m, deepClone)
+}
+
+func deepClone(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return DeepCloneAny(v)
+	case []any:
+		if v == nil {
+			return v
+		}
+		r := make([]any, len(v))
+		for i, item := range v {
+			r[i] = deepClone(item)
+		}
+		return r
+	}
+	return v
+}
@@ -0,0 +1,30 @@
+package maps
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDeepMergeCopiesSlices(t *testing.T) {
+	for _, strategy := range []SliceStrategy{SliceReplace, SliceAppend} {
+		src := map[string]any{"s": []any{map[string]any{"a": 1}}}
+		dst := map[string]any{"s": []any{}}
+		DeepMerge(dst, src, DeepMergeOptions{Slices: strategy})
+		dst["s"].([]any)[0].(map[string]any)["a"] = 2
+		if src["s"].([]any)[0].(map[string]any)["a"] != 1 {
+			t.Fatalf("strategy %d shared src", strategy)
+		}
+	}
+}
+
+func TestUnflattenConflictWithPrefixValue(t *testing.T) {
+	m := map[string]any{"a.b": map[string]any{}, "a.b.c": 1}
+	for range 50 {
+		if _, err := Unflatten(m, "."); !errors.Is(err, ErrPathConflict) {
+			t.Fatalf("err = %v", err)
+		}
+	}
+	if len(m["a.b"].(map[string]any)) != 0 {
+		t.Fatal("Unflatten changed its input")
+	}
+}
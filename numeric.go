@@ -0,0 +1,56 @@
+package maps
+
+// SumValues returns the sum of the values of a map
+func SumValues[M ~map[K]V, K comparable, V Number](m M) (sum V) {
+	for _, v := range m {
+		sum += v
+	}
+	return
+}
+
+// MeanValues returns the mean of the values of a map, and false if the map is empty
+func MeanValues[M ~map[K]V, K comparable, V Number](m M) (mean float64, ok bool) {
+	if len(m) == 0 {
+		return 0, false
+	}
+	var sum float64
+	for _, v := range m {
+		sum += float64(v)
+	}
+	return sum / float64(len(m)), true
+}
+
+// MinMaxValues returns the least and greatest values of a map, and false if the map is empty
+func MinMaxValues[M ~map[K]V, K comparable, V Number](m M) (min, max V, ok bool) {
+	for _, v := range m {
+		if !ok {
+			min, max, ok = v, v, true
+		} else if v < min {
+			min = v
+		} else if v > max {
+			max = v
+		}
+	}
+	return
+}
+
+// SumValuesSync calls SumValues inside the RWMutex read lock state
+func SumValuesSync[K comparable, V Number](s *Sync[K, V]) V {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return SumValues(s.data)
+}
+
+// MeanValuesSync calls MeanValues inside the RWMutex read lock state
+func MeanValuesSync[K comparable, V Number](s *Sync[K, V]) (float64, bool) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return MeanValues(s.data)
+}
+
+// MinMaxValuesSync calls MinMaxValues inside the RWMutex read lock state
+func MinMaxValuesSync[K comparable, V Number](s *Sync[K, V]) (min, max V, ok bool) {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return MinMaxValues(s.data)
+}
@@ -1,5 +1,11 @@
 package maps
 
+import (
+	"context"
+	"sync"
+	"time"
+)
+
 // Observer is an interface for observing a generic type
 type Observer[K comparable, V any] interface {
 	Observe(id K, new, old V)
@@ -10,28 +16,251 @@ type ObserverFunc[K comparable, V any] func(id K, new, old V)
 
 func (f ObserverFunc[K, V]) Observe(id K, new, old V) { f(id, new, old) }
 
+// Middleware wraps an observer, see Observable.Use
+//
+// Every observer is delivered to as a BatchObserver, so the Kind, Seq, and Time of every Event reach next
+type Middleware[K comparable, V any] func(next BatchObserver[K, V]) BatchObserver[K, V]
+
+// BeforeSetFunc is a hook called before a value is set, which rejects the change by returning an error
+//
+// e.Kind is EventCreate or EventUpdate, and e.Seq is not yet assigned
+type BeforeSetFunc[K comparable, V any] func(e Event[K, V]) error
+
 // Observable is a generic observable map
 type Observable[K comparable, V any] struct {
-	sync Sync[K, V]
-	obs  []Observer[K, V]
+	sync       Sync[K, V]
+	obsMu      sync.Mutex
+	obs        []observer[K, V] // copy-on-write, guarded by obsMu
+	obsID      uint64
+	batch      []Event[K, V]
+	seq        uint64
+	now        func() time.Time
+	history    history[K, V]
+	undo       *undoStack[K, V]
+	ttl        map[K]*expiry
+	stopped    []stoppedTTL[K] // expiries stopped by the queued changes
+	undoing    bool            // the queued changes are not a step for Undo
+	before     []BeforeSetFunc[K, V]
+	checked    []checkedObserver[K, V]
+	jsonNotify bool
+	mw         []Middleware[K, V] // guarded by obsMu
+}
+
+// observer is a registered Observer, and the same Observer wrapped with Middleware
+type observer[K comparable, V any] struct {
+	id       uint64
+	priority int
+	obs      Observer[K, V]
+	wrapped  BatchObserver[K, V]
 }
 
 // NewObservable creates an empty *Observable[K, V]
 func NewObservable[K comparable, V any]() *Observable[K, V] {
 	return &Observable[K, V]{
 		sync: Sync[K, V]{data: make(map[K]V)},
-		obs:  make([]Observer[K, V], 0),
+		obs:  make([]observer[K, V], 0),
+		now:  time.Now,
+	}
+}
+
+// NewObservableFrom creates an *Observable[K, V] with a shallow clone of a map, without notifying observers
+func NewObservableFrom[M ~map[K]V, K comparable, V any](m M) *Observable[K, V] {
+	o := NewObservable[K, V]()
+	Copy(o.sync.data, m)
+	return o
+}
+
+// NewObservableFromSync creates an *Observable[K, V] with a shallow clone of a *Sync, without notifying observers
+func NewObservableFromSync[K comparable, V any](s *Sync[K, V]) *Observable[K, V] {
+	o := NewObservable[K, V]()
+	s.rw.RLock()
+	Copy(o.sync.data, s.data)
+	s.rw.RUnlock()
+	return o
+}
+
+// Clone returns a new *Observable with a shallow clone of the data, and no observers
+func (o *Observable[K, V]) Clone() *Observable[K, V] {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	return NewObservableFrom(o.sync.data)
+}
+
+// emit sequences and records a change, and queues it until unlock
+func (o *Observable[K, V]) emit(e Event[K, V]) {
+	o.seq++
+	e.Seq, e.Time = o.seq, o.now()
+	o.history.push(e)
+	o.batch = append(o.batch, e)
+}
+
+// unlock notifies observers of the queued changes, then releases the write lock
+func (o *Observable[K, V]) unlock() {
+	if events := o.batch; len(events) > 0 {
+		o.batch = nil
+		if o.undo != nil && !o.undoing {
+			o.undo.push(events)
+		}
+		o.notify(events)
+	}
+	o.stopped = nil
+	o.undoing = false
+	o.sync.rw.Unlock()
+}
+
+// atomic calls f inside the RWMutex write lock state, and reverts every change made by f, without notifying
+// observers, if f returns an error or panics
+func (o *Observable[K, V]) atomic(f func() error) (err error) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	mark, done := len(o.batch), false
+	defer func() {
+		if !done {
+			o.abort(mark)
+		}
+	}()
+	err = f()
+	done = err == nil
+	return
+}
+
+// abort reverts the queued changes after mark, newest first, and rolls back every CheckedObserver which prepared them
+func (o *Observable[K, V]) abort(mark int) {
+	for i := len(o.batch) - 1; i >= mark; i-- {
+		e := o.batch[i]
+		if e.Kind == EventCreate {
+			delete(o.sync.data, e.Key)
+		} else {
+			o.sync.data[e.Key] = e.Old
+		}
+		o.seq--
+		o.history.pop()
+		e.Seq, e.Time = 0, time.Time{}
+		o.rollback(e)
+	}
+	o.batch = o.batch[:mark]
+	o.restoreTTL(mark)
+}
+
+func (o *Observable[K, V]) notify(events []Event[K, V]) {
+	for _, obs := range o.observers() {
+		obs.wrapped.ObserveBatch(events)
+	}
+}
+
+// observers returns the current observers, which must not be modified
+func (o *Observable[K, V]) observers() []observer[K, V] {
+	o.obsMu.Lock()
+	defer o.obsMu.Unlock()
+	return o.obs
+}
+
+func (o *Observable[K, V]) wrap(f Observer[K, V]) BatchObserver[K, V] {
+	b := asBatch(f)
+	for i := len(o.mw) - 1; i >= 0; i-- {
+		b = o.mw[i](b)
+	}
+	return b
+}
+
+func (o *Observable[K, V]) subscribe(priority int, f Observer[K, V]) func() {
+	id := o.newObserverID()
+	o.insert(id, priority, f)
+	return func() { o.unsubscribe(id) }
+}
+
+func (o *Observable[K, V]) newObserverID() uint64 {
+	o.obsMu.Lock()
+	defer o.obsMu.Unlock()
+	o.obsID++
+	return o.obsID
+}
+
+// insert adds an observer after every observer with the same or higher priority, and returns it wrapped with
+// Middleware
+func (o *Observable[K, V]) insert(id uint64, priority int, f Observer[K, V]) BatchObserver[K, V] {
+	o.obsMu.Lock()
+	defer o.obsMu.Unlock()
+	wrapped := o.wrap(f)
+	i := len(o.obs)
+	for i > 0 && o.obs[i-1].priority < priority {
+		i--
 	}
+	obs := make([]observer[K, V], 0, len(o.obs)+1)
+	obs = append(obs, o.obs[:i]...)
+	obs = append(obs, observer[K, V]{id: id, priority: priority, obs: f, wrapped: wrapped})
+	o.obs = append(obs, o.obs[i:]...)
+	return wrapped
 }
 
-func (o *Observable[K, V]) callback(key K, new, old V) {
-	for _, o := range o.obs {
-		o.Observe(key, new, old)
+func (o *Observable[K, V]) unsubscribe(id uint64) {
+	o.obsMu.Lock()
+	defer o.obsMu.Unlock()
+	for i, obs := range o.obs {
+		if obs.id == id {
+			o.obs = append(o.obs[:i:i], o.obs[i+1:]...)
+			return
+		}
 	}
 }
-func (o *Observable[K, V]) set(key K, val V) {
-	o.callback(key, val, o.sync.data[key])
-	o.sync.data[key] = val
+
+func (o *Observable[K, V]) set(key K, val V) error {
+	e := o.setEvent(key, val)
+	if err := o.check(e); err != nil {
+		return err
+	}
+	o.store(e)
+	return nil
+}
+
+// check calls BeforeSet hooks and CheckedObservers for a change which is not yet stored
+func (o *Observable[K, V]) check(e Event[K, V]) error {
+	for _, f := range o.before {
+		if err := f(e); err != nil {
+			return err
+		}
+	}
+	return o.prepare(e)
+}
+
+// put changes the value for a key without calling BeforeSet hooks
+func (o *Observable[K, V]) put(key K, val V) { o.store(o.setEvent(key, val)) }
+
+func (o *Observable[K, V]) setEvent(key K, val V) Event[K, V] {
+	old, ok := o.sync.data[key]
+	e := Event[K, V]{Kind: EventUpdate, Key: key, New: val, Old: old}
+	if !ok {
+		e.Kind = EventCreate
+	}
+	return e
+}
+
+func (o *Observable[K, V]) store(e Event[K, V]) {
+	o.sync.data[e.Key] = e.New
+	o.stopTTL(e.Key)
+	o.emit(e)
+}
+
+// delete deletes a key, unless a CheckedObserver rejects the change
+func (o *Observable[K, V]) delete(key K) (old V, ok bool, err error) {
+	if old, ok = o.sync.data[key]; !ok {
+		return
+	} else if err = o.prepare(Event[K, V]{Kind: EventDelete, Key: key, Old: old}); err != nil {
+		return old, false, err
+	}
+	o.remove(key)
+	return
+}
+
+// remove deletes a key without calling CheckedObservers
+func (o *Observable[K, V]) remove(key K) (old V, ok bool) {
+	if old, ok = o.sync.data[key]; !ok {
+		return
+	}
+	delete(o.sync.data, key)
+	o.stopTTL(key)
+	o.emit(Event[K, V]{Kind: EventDelete, Key: key, Old: old})
+	return
 }
 
 // Keys returns the keys
@@ -54,16 +283,221 @@ func (o *Observable[K, V]) Get(key K) V {
 	return o.sync.Get(key)
 }
 
+// GetOk returns the value for a key, and whether the key is present
+func (o *Observable[K, V]) GetOk(key K) (V, bool) {
+	return o.sync.GetOk(key)
+}
+
+// LoadOrStore returns the value for a key if present, otherwise stores and returns the given value
+//
+// loaded is true if the value was present, and err is the error from the first BeforeSetFunc or CheckedObserver to
+// reject the change
+func (o *Observable[K, V]) LoadOrStore(key K, val V) (actual V, loaded bool, err error) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if actual, loaded = o.sync.data[key]; loaded {
+		return
+	} else if err = o.set(key, val); err != nil {
+		return
+	}
+	return val, false, nil
+}
+
+// Swap changes the value for a key, and returns the previous value, with a single EventCreate or EventUpdate
+//
+// loaded is true if the key was present, and err is the error from the first BeforeSetFunc or CheckedObserver to
+// reject the change
+func (o *Observable[K, V]) Swap(key K, val V) (previous V, loaded bool, err error) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	previous, loaded = o.sync.data[key]
+	err = o.set(key, val)
+	return
+}
+
+// CompareAndSwap changes the value for a key, if the key is present and eq reports the current value is equal to old
+//
+// err is the error from the first BeforeSetFunc or CheckedObserver to reject the change
+func (o *Observable[K, V]) CompareAndSwap(key K, old, new V, eq func(a, b V) bool) (swapped bool, err error) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if cur, ok := o.sync.data[key]; !ok || !eq(cur, old) {
+		return false, nil
+	} else if err = o.set(key, new); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // Set changes the value for a key
-func (o *Observable[K, V]) Set(key K, val V) {
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject the change
+func (o *Observable[K, V]) Set(key K, val V) error {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	return o.set(key, val)
+}
+
+// SetIfChanged changes the value for a key, unless eq reports the new value is equal to the current value
+//
+// returns whether the value was changed, and the error from the first BeforeSetFunc or CheckedObserver to reject
+// the change
+func (o *Observable[K, V]) SetIfChanged(key K, val V, eq func(a, b V) bool) (bool, error) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if old, ok := o.sync.data[key]; ok && eq(old, val) {
+		return false, nil
+	}
+	if err := o.set(key, val); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetObservableIfChanged calls SetIfChanged using == to compare values
+func SetObservableIfChanged[K comparable, V comparable](o *Observable[K, V], key K, val V) (bool, error) {
+	return o.SetIfChanged(key, val, func(a, b V) bool { return a == b })
+}
+
+// Update calls a function with the current value for a key, inside the RWMutex write lock state, and stores the
+// returned value, or deletes the key if f returns false
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject the change
+func (o *Observable[K, V]) Update(key K, f func(cur V, exists bool) (V, bool)) error {
 	o.sync.rw.Lock()
-	o.set(key, val)
+	defer o.unlock()
+	cur, exists := o.sync.data[key]
+	if val, ok := f(cur, exists); ok {
+		return o.set(key, val)
+	}
+	_, _, err := o.delete(key)
+	return err
+}
+
+// BeforeSet adds a hook which is called before every value is set, which can reject the change
+func (o *Observable[K, V]) BeforeSet(f BeforeSetFunc[K, V]) {
+	o.sync.rw.Lock()
+	o.before = append(o.before, f)
 	o.sync.rw.Unlock()
 }
 
-// Observe adds an observer
+// Observe adds an observer, with priority 0
+//
+// Observers are notified in order of priority, highest first, and observers with the same priority are notified in
+// the order they were added
+//
+// Observers may be added and removed concurrently with writes, and from inside an observer
 func (o *Observable[K, V]) Observe(f Observer[K, V]) {
-	o.obs = append(o.obs, f)
+	o.subscribe(0, f)
+}
+
+// ObserveWithPriority adds an observer with a priority, and returns a func to remove it
+func (o *Observable[K, V]) ObserveWithPriority(priority int, f Observer[K, V]) (cancel func()) {
+	return o.subscribe(priority, f)
+}
+
+// Subscribe adds an observer, with priority 0, and returns a func to remove it
+func (o *Observable[K, V]) Subscribe(f Observer[K, V]) (cancel func()) {
+	return o.subscribe(0, f)
+}
+
+// ObserveCtx adds an observer, with priority 0, which is removed when ctx is done, or cancel is called
+func (o *Observable[K, V]) ObserveCtx(ctx context.Context, f Observer[K, V]) (cancel func()) {
+	unsubscribe := o.subscribe(0, f)
+	stop := context.AfterFunc(ctx, unsubscribe)
+	return func() {
+		stop()
+		unsubscribe()
+	}
+}
+
+// Use adds a Middleware which wraps every observer, including observers already added
+//
+// Middleware added first is outermost
+func (o *Observable[K, V]) Use(mw Middleware[K, V]) {
+	o.obsMu.Lock()
+	o.mw = append(o.mw, mw)
+	obs := make([]observer[K, V], len(o.obs))
+	for i, ob := range o.obs {
+		ob.wrapped = o.wrap(ob.obs)
+		obs[i] = ob
+	}
+	o.obs = obs
+	o.obsMu.Unlock()
+}
+
+// SnapshotAndSubscribe returns a shallow clone of the data, and adds an observer, with no change between
+//
+// The observer receives exactly the changes made after the snapshot
+func (o *Observable[K, V]) SnapshotAndSubscribe(f Observer[K, V]) (snapshot map[K]V, cancel func()) {
+	o.sync.rw.RLock()
+	snapshot = Clone(o.sync.data)
+	cancel = o.subscribe(0, f)
+	o.sync.rw.RUnlock()
+	return
+}
+
+// KeepHistory sets the number of recent events kept for Replay, and keeps the most recent events already kept
+//
+// KeepHistory panics if n is negative
+func (o *Observable[K, V]) KeepHistory(n int) {
+	if n < 0 {
+		panic("maps: history size must not be negative")
+	}
+	o.sync.rw.Lock()
+	events := o.history.events()
+	o.history = newHistory[K, V](n)
+	if len(events) > n {
+		events = events[len(events)-n:]
+	}
+	for _, e := range events {
+		o.history.push(e)
+	}
+	o.sync.rw.Unlock()
+}
+
+// SetClock sets the func used to timestamp events and compute TTL deadlines, which is time.Now by default
+func (o *Observable[K, V]) SetClock(now func() time.Time) {
+	o.sync.rw.Lock()
+	o.now = now
+	o.sync.rw.Unlock()
+}
+
+// Seq returns the sequence number of the latest event
+func (o *Observable[K, V]) Seq() uint64 {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	return o.seq
+}
+
+// Replay returns the kept events, starting with sequence number from
+//
+// returns ErrHistoryGap if any events since from are no longer kept
+func (o *Observable[K, V]) Replay(from uint64) ([]Event[K, V], error) {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	return o.history.since(from, o.seq)
+}
+
+// SubscribeWithReplay adds an observer, and delivers the kept events, starting with sequence number from, with no gap
+// before later events
+//
+// The replayed events pass through Middleware, like later events, and are delivered inside the RWMutex read lock
+// state, so f must not write to the Observable while replaying. returns ErrHistoryGap, and does not add the observer,
+// if any events since from are no longer kept
+func (o *Observable[K, V]) SubscribeWithReplay(from uint64, f Observer[K, V]) (cancel func(), err error) {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	events, err := o.history.since(from, o.seq)
+	if err != nil {
+		return nil, err
+	}
+	id := o.newObserverID()
+	wrapped := o.insert(id, 0, f)
+	if len(events) > 0 {
+		wrapped.ObserveBatch(events)
+	}
+	return func() { o.unsubscribe(id) }, nil
 }
 
 // Each calls a function, once for every value, inside the mutex lock state
@@ -86,36 +520,135 @@ func ReduceObservable[K comparable, V any, A any](o *Observable[K, V], a A, f fu
 	return ReduceSync(&o.sync, a, f)
 }
 
+// EqualObservable returns whether 2 *Observable have the same entries
+func EqualObservable[K comparable, V comparable](a, b *Observable[K, V]) bool {
+	return EqualSync(&a.sync, &b.sync)
+}
+
+// EqualFuncObservable returns whether 2 *Observable have the same keys, with values that eq reports are equal
+func EqualFuncObservable[K comparable, V1 any, V2 any](a *Observable[K, V1], b *Observable[K, V2], eq func(V1, V2) bool) bool {
+	return EqualFuncSync(&a.sync, &b.sync, eq)
+}
+
 // Delete deletes keys
-func (o *Observable[K, V]) Delete(keys ...K) {
-	var zero V
+//
+// keys which are not present are not changed, so they do not notify observers. returns the error from the first
+// CheckedObserver to reject a change, in which case no key is deleted
+func (o *Observable[K, V]) Delete(keys ...K) error {
+	return o.atomic(func() error {
+		for _, key := range keys {
+			if _, _, err := o.delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetAndDelete deletes a key, and returns the removed value, with a single EventDelete
+//
+// loaded is true if the key was deleted, and err is the error from a CheckedObserver which rejected the change
+func (o *Observable[K, V]) GetAndDelete(key K) (val V, loaded bool, err error) {
 	o.sync.rw.Lock()
-	for _, key := range keys {
-		o.callback(key, zero, o.sync.data[key])
-		delete(o.sync.data, key)
+	defer o.unlock()
+	return o.delete(key)
+}
+
+// DeleteReturning deletes keys, and returns the removed values, for only the keys which were present
+//
+// returns the error from the first CheckedObserver to reject a change, in which case no key is deleted
+func (o *Observable[K, V]) DeleteReturning(keys ...K) (map[K]V, error) {
+	removed := make(map[K]V, len(keys))
+	err := o.atomic(func() error {
+		for _, key := range keys {
+			if old, ok, err := o.delete(key); err != nil {
+				return err
+			} else if ok {
+				removed[key] = old
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
-	o.sync.rw.Unlock()
+	return removed, nil
 }
 
 // DeleteFunc deletes where del returns true
-func (o *Observable[K, V]) DeleteFunc(del func(K, V) bool) {
-	var zero V
-	o.sync.rw.Lock()
-	for k, v := range o.sync.data {
-		if del(k, v) {
-			o.callback(k, zero, v)
-			delete(o.sync.data, k)
+//
+// returns the error from the first CheckedObserver to reject a change, in which case no key is deleted
+func (o *Observable[K, V]) DeleteFunc(del func(K, V) bool) error {
+	return o.atomic(func() error {
+		for k, v := range o.sync.data {
+			if !del(k, v) {
+				continue
+			} else if _, _, err := o.delete(k); err != nil {
+				return err
+			}
 		}
-	}
-	o.sync.rw.Unlock()
+		return nil
+	})
 }
 
 // Lock calls a function inside the RWMutex write lock state
-func (o *Observable[K, V]) Lock(f func(set func(K, V))) {
-	o.sync.rw.Lock()
-	f(o.set)
-	o.sync.rw.Unlock()
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject a value, after which later values are
+// not set, and the values already set are reverted without notifying observers
+func (o *Observable[K, V]) Lock(f func(set func(K, V))) error {
+	return o.atomic(func() (err error) {
+		f(func(key K, val V) {
+			if err == nil {
+				err = o.set(key, val)
+			}
+		})
+		return
+	})
 }
 
 // RLock calls a function inside the RWMutex read lock state
 func (o *Observable[K, V]) RLock(f func()) { o.sync.RLock(f) }
+
+// Tx is a write transaction on an Observable, see Observable.Txn
+type Tx[K comparable, V any] struct {
+	o   *Observable[K, V]
+	err error
+}
+
+// Get returns the value for a key, including changes made in this Tx
+func (tx *Tx[K, V]) Get(key K) V { return tx.o.sync.data[key] }
+
+// Set changes the value for a key
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject the change, which fails the Tx, so
+// every later Set returns the same error without making a change
+func (tx *Tx[K, V]) Set(key K, val V) error {
+	if tx.err == nil {
+		tx.err = tx.o.set(key, val)
+	}
+	return tx.err
+}
+
+// Delete deletes keys
+//
+// returns the error from the first CheckedObserver to reject a change, which fails the Tx, like Set
+func (tx *Tx[K, V]) Delete(keys ...K) error {
+	for i := 0; i < len(keys) && tx.err == nil; i++ {
+		_, _, tx.err = tx.o.delete(keys[i])
+	}
+	return tx.err
+}
+
+// Txn calls a function inside the RWMutex write lock state, and notifies observers once with every change made by the Tx
+//
+// Observers which implement BatchObserver receive all changes in a single call. If f returns an error, or panics, or
+// the Tx fails, every change made by the Tx is reverted without notifying observers, and the error is returned
+func (o *Observable[K, V]) Txn(f func(tx *Tx[K, V]) error) error {
+	return o.atomic(func() error {
+		tx := &Tx[K, V]{o: o}
+		if err := f(tx); err != nil {
+			return err
+		}
+		return tx.err
+	})
+}
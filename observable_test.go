@@ -0,0 +1,328 @@
+package maps
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func passThrough[K comparable, V any](next BatchObserver[K, V]) BatchObserver[K, V] {
+	return BatchObserverFunc[K, V](next.ObserveBatch)
+}
+
+func TestMiddlewareKeepsEventKind(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.Use(passThrough[string, int])
+	r := NewRecorder[string, int]()
+	o.Observe(r)
+	dst := NewSync[string, int]()
+	o.Mirror(dst)
+	var buf bytes.Buffer
+	j := NewJournal[string, int](json.NewEncoder(&buf))
+	o.Observe(j)
+
+	o.Set("a", 1)
+	o.Delete("a")
+
+	events := r.Events()
+	if len(events) != 2 || events[0].Kind != EventCreate || events[1].Kind != EventDelete {
+		t.Fatalf("events = %v", events)
+	}
+	if _, ok := dst.GetOk("a"); ok {
+		t.Fatal("Mirror kept deleted key")
+	}
+	restored := NewObservable[string, int]()
+	if err := restored.Restore(json.NewDecoder(&buf)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := restored.GetOk("a"); ok {
+		t.Fatal("Restore brought back deleted key")
+	}
+}
+
+func TestMiddlewareWrapsPlainObserver(t *testing.T) {
+	o := NewObservable[string, int]()
+	var kinds []EventKind
+	o.Use(func(next BatchObserver[string, int]) BatchObserver[string, int] {
+		return BatchObserverFunc[string, int](func(events []Event[string, int]) {
+			for _, e := range events {
+				kinds = append(kinds, e.Kind)
+			}
+			next.ObserveBatch(events)
+		})
+	})
+	var calls int
+	o.Observe(ObserverFunc[string, int](func(string, int, int) { calls++ }))
+
+	o.Set("a", 1)
+	o.Delete("a")
+
+	if calls != 2 || len(kinds) != 2 || kinds[1] != EventDelete {
+		t.Fatalf("calls = %d, kinds = %v", calls, kinds)
+	}
+}
+
+func TestDeleteAbsentKeyDoesNotNotify(t *testing.T) {
+	o := NewObservableFrom(map[string]int{"a": 1})
+	size := SizeOf(o)
+	defer size.Close()
+	r := NewRecorder[string, int]()
+	o.Observe(r)
+
+	o.Delete("missing", "a")
+
+	if events := r.Events(); len(events) != 1 || events[0].Key != "a" {
+		t.Fatalf("events = %v", events)
+	}
+	if n := size.Get(); n != 0 {
+		t.Fatalf("SizeOf = %d", n)
+	}
+}
+
+func rejectKey[V any](key string) BeforeSetFunc[string, V] {
+	return func(e Event[string, V]) error {
+		if e.Key == key {
+			return errors.New("rejected " + key)
+		}
+		return nil
+	}
+}
+
+func TestTxnRejectionRevertsEarlierWrites(t *testing.T) {
+	o := NewObservableFrom(map[string]int{"c": 3})
+	o.KeepHistory(10)
+	o.BeforeSet(rejectKey[int]("b"))
+	r := NewRecorder[string, int]()
+	o.Observe(r)
+
+	err := o.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("a", 1)
+		tx.Delete("c")
+		tx.Set("b", 2)
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("Txn returned nil error")
+	}
+	if _, ok := o.GetOk("a"); ok {
+		t.Fatal("a was kept")
+	}
+	if o.Get("c") != 3 {
+		t.Fatal("c was not restored")
+	}
+	if r.Len() != 0 || o.Seq() != 0 {
+		t.Fatalf("events = %v, seq = %d", r.Events(), o.Seq())
+	}
+	if events, _ := o.Replay(1); len(events) != 0 {
+		t.Fatalf("history = %v", events)
+	}
+}
+
+func TestTxnErrorAndPanicRevert(t *testing.T) {
+	o := NewObservable[string, int]()
+	fail := errors.New("fail")
+	if err := o.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("a", 1)
+		return fail
+	}); err != fail {
+		t.Fatalf("err = %v", err)
+	}
+	func() {
+		defer func() { recover() }()
+		o.Txn(func(tx *Tx[string, int]) error {
+			tx.Set("b", 1)
+			panic("boom")
+		})
+	}()
+	if o.Size() != 0 {
+		t.Fatalf("keys = %v", o.Keys())
+	}
+	if err := o.Set("c", 1); err != nil || o.Get("c") != 1 {
+		t.Fatal("Observable is still locked or broken", err)
+	}
+}
+
+func TestLockRejectionReverts(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.BeforeSet(rejectKey[int]("b"))
+	err := o.Lock(func(set func(string, int)) {
+		set("a", 1)
+		set("b", 2)
+		set("c", 3)
+	})
+	if err == nil || o.Size() != 0 {
+		t.Fatalf("err = %v, keys = %v", err, o.Keys())
+	}
+}
+
+type checkedLog struct {
+	reject   string
+	prepared []Event[string, int]
+	rolled   []Event[string, int]
+}
+
+func (c *checkedLog) Prepare(e Event[string, int]) error {
+	if e.Key == c.reject {
+		return errors.New("rejected " + e.Key)
+	}
+	c.prepared = append(c.prepared, e)
+	return nil
+}
+
+func (c *checkedLog) Rollback(e Event[string, int]) { c.rolled = append(c.rolled, e) }
+
+func TestCheckedObserverVetoesDelete(t *testing.T) {
+	o := NewObservableFrom(map[string]int{"a": 1, "b": 2})
+	c := &checkedLog{reject: "b"}
+	o.ObserveChecked(c)
+
+	if err := o.Delete("a", "b"); err == nil {
+		t.Fatal("Delete returned nil error")
+	}
+	if o.Size() != 2 {
+		t.Fatalf("keys = %v", o.Keys())
+	}
+	if len(c.prepared) != 1 || c.prepared[0].Kind != EventDelete || len(c.rolled) != 1 || c.rolled[0].Key != "a" {
+		t.Fatalf("prepared = %v, rolled = %v", c.prepared, c.rolled)
+	}
+	if _, loaded, err := o.GetAndDelete("b"); loaded || err == nil {
+		t.Fatalf("loaded = %v, err = %v", loaded, err)
+	}
+}
+
+func TestTxnRollsBackPreparedChanges(t *testing.T) {
+	o := NewObservableFrom(map[string]int{"c": 3})
+	c := &checkedLog{reject: "b"}
+	o.ObserveChecked(c)
+
+	err := o.Txn(func(tx *Tx[string, int]) error {
+		tx.Set("a", 1)
+		tx.Delete("c")
+		return tx.Set("b", 2)
+	})
+
+	if err == nil {
+		t.Fatal("Txn returned nil error")
+	}
+	if len(c.rolled) != 2 || c.rolled[0].Key != "c" || c.rolled[1].Key != "a" {
+		t.Fatalf("rolled = %v", c.rolled)
+	}
+}
+
+func TestInsertSeqIsAtomic(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.BeforeSet(rejectKey[int]("b"))
+	seq := func(yield func(string, int) bool) {
+		_ = yield("a", 1) && yield("b", 2) && yield("c", 3)
+	}
+	if err := o.InsertSeq(seq); err == nil || o.Size() != 0 {
+		t.Fatalf("err = %v, keys = %v", err, o.Keys())
+	}
+}
+
+func TestTTLUsesClockAndSkipsUndo(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	o := NewObservable[string, int]()
+	o.SetClock(func() time.Time { return now })
+	o.EnableUndo(10)
+
+	o.Set("a", 1)
+	o.SetWithTTL("b", 2, time.Hour)
+	if d, ok := o.TTL("b"); !ok || d != time.Hour {
+		t.Fatalf("TTL = %v, %v", d, ok)
+	}
+	o.SetWithTTL("c", 3, time.Minute)
+	if o.Undo(1) != 1 || o.Size() != 2 {
+		t.Fatalf("keys = %v", o.Keys())
+	}
+
+	if n := o.ExpireDue(); n != 0 {
+		t.Fatalf("ExpireDue = %d before deadline", n)
+	}
+	now = now.Add(2 * time.Hour)
+	if n := o.ExpireDue(); n != 1 {
+		t.Fatalf("ExpireDue = %d", n)
+	}
+	if _, ok := o.GetOk("b"); ok {
+		t.Fatal("b did not expire")
+	}
+	if o.Redo(1) != 1 || o.Get("c") != 3 {
+		t.Fatal("expiry discarded the redo step")
+	}
+	if o.Undo(2) != 2 || o.Size() != 1 {
+		t.Fatalf("undo after expiry: keys = %v", o.Keys())
+	}
+}
+
+func TestSubscribeWithReplayUsesMiddleware(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.KeepHistory(10)
+	o.Set("a", 1)
+	o.Delete("a")
+	var replayed []EventKind
+	o.Use(func(next BatchObserver[string, int]) BatchObserver[string, int] {
+		return BatchObserverFunc[string, int](func(events []Event[string, int]) {
+			for _, e := range events {
+				replayed = append(replayed, e.Kind)
+			}
+			next.ObserveBatch(events)
+		})
+	})
+	r := NewRecorder[string, int]()
+	cancel, err := o.SubscribeWithReplay(1, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+	o.Set("b", 2)
+
+	if len(replayed) != 3 || replayed[1] != EventDelete || r.Len() != 3 {
+		t.Fatalf("replayed = %v, events = %v", replayed, r.Events())
+	}
+}
+
+func TestSetReleasesLockOnPanic(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.BeforeSet(func(e Event[string, int]) error { panic("boom") })
+	func() {
+		defer func() { recover() }()
+		o.Set("a", 1)
+	}()
+	if keys := o.Keys(); len(keys) != 0 {
+		t.Fatalf("keys = %v", keys)
+	}
+}
+
+func TestRestoreDropsTTLAndUndo(t *testing.T) {
+	var buf bytes.Buffer
+	src := NewObservable[string, int]()
+	src.Observe(NewJournal[string, int](json.NewEncoder(&buf)))
+	src.Set("a", 1)
+	src.Delete("a")
+
+	o := NewObservable[string, int]()
+	o.EnableUndo(10)
+	o.KeepHistory(10)
+	o.SetWithTTL("a", 5, time.Millisecond)
+	if err := o.Restore(json.NewDecoder(&buf)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := o.TTL("a"); ok {
+		t.Fatal("a kept its TTL")
+	}
+	if n := o.Undo(1); n != 0 {
+		t.Fatalf("Undo = %d after Restore", n)
+	}
+	if o.Seq() != 2 {
+		t.Fatalf("seq = %d", o.Seq())
+	}
+	if events, err := o.Replay(2); err != nil || len(events) != 1 || events[0].Kind != EventDelete {
+		t.Fatalf("Replay = %v, %v", events, err)
+	}
+	if _, err := o.Replay(1); err != ErrHistoryGap {
+		t.Fatalf("Replay(1) err = %v", err)
+	}
+}
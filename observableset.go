@@ -0,0 +1,68 @@
+package maps
+
+// SetObserver is an interface for observing an ObservableSet
+type SetObserver[T comparable] interface {
+	ObserveSet(item T, added bool)
+}
+
+// SetObserverFunc is a func type that implements SetObserver
+type SetObserverFunc[T comparable] func(item T, added bool)
+
+func (f SetObserverFunc[T]) ObserveSet(item T, added bool) { f(item, added) }
+
+// setObserver adapts a SetObserver to Observer
+type setObserver[T comparable] struct {
+	f SetObserver[T]
+}
+
+func (s setObserver[T]) Observe(id T, new, old struct{}) { observeSingle(s, id, new, old) }
+
+func (s setObserver[T]) ObserveBatch(events []Event[T, struct{}]) {
+	for _, e := range events {
+		s.f.ObserveSet(e.Key, !e.Kind.IsDelete())
+	}
+}
+
+// ObservableSet is a generic observable Set
+type ObservableSet[T comparable] struct {
+	o *Observable[T, struct{}]
+}
+
+// NewObservableSet creates an empty *ObservableSet[T]
+func NewObservableSet[T comparable]() *ObservableSet[T] {
+	return &ObservableSet[T]{o: NewObservable[T, struct{}]()}
+}
+
+// Has checks value is in ObservableSet
+func (s *ObservableSet[T]) Has(t T) bool {
+	_, ok := s.o.GetOk(t)
+	return ok
+}
+
+// Add stores a value, and notifies observers if it was not present
+func (s *ObservableSet[T]) Add(t T) { s.o.LoadOrStore(t, struct{}{}) }
+
+// Remove deletes a value, and notifies observers if it was present
+func (s *ObservableSet[T]) Remove(t T) { s.o.Delete(t) }
+
+// Delete deletes items
+func (s *ObservableSet[T]) Delete(items ...T) { s.o.Delete(items...) }
+
+// Size returns the number of items
+func (s *ObservableSet[T]) Size() int { return s.o.Size() }
+
+// Slice returns the items as []T
+func (s *ObservableSet[T]) Slice() []T { return s.o.Keys() }
+
+// Each calls a function once for every value, inside the mutex lock state
+func (s *ObservableSet[T]) Each(f func(v T)) {
+	s.o.Each(func(t T, _ struct{}) { f(t) })
+}
+
+// Observe adds an observer
+func (s *ObservableSet[T]) Observe(f SetObserver[T]) { s.o.Observe(setObserver[T]{f}) }
+
+// Subscribe adds an observer, and returns a func to remove it
+func (s *ObservableSet[T]) Subscribe(f SetObserver[T]) (cancel func()) {
+	return s.o.Subscribe(setObserver[T]{f})
+}
@@ -0,0 +1,40 @@
+package maps
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// ObserveOnce adds an observer which receives only the first Event where match returns true, then is removed
+//
+// match may be nil to match any Event
+func (o *Observable[K, V]) ObserveOnce(match func(Event[K, V]) bool, f Observer[K, V]) (cancel func()) {
+	var done atomic.Bool
+	id := o.newObserverID()
+	cancel = func() { o.unsubscribe(id) }
+	o.insert(id, 0, BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		for _, e := range events {
+			if (match == nil || match(e)) && done.CompareAndSwap(false, true) {
+				cancel()
+				notify(f, []Event[K, V]{e})
+				return
+			}
+		}
+	}))
+	return
+}
+
+// WatchOnce waits for the first Event where match returns true, or returns ctx.Err()
+//
+// match may be nil to match any Event
+func (o *Observable[K, V]) WatchOnce(ctx context.Context, match func(Event[K, V]) bool) (Event[K, V], error) {
+	ch := make(chan Event[K, V], 1)
+	cancel := o.ObserveOnce(match, BatchObserverFunc[K, V](func(events []Event[K, V]) { ch <- events[0] }))
+	defer cancel()
+	select {
+	case e := <-ch:
+		return e, nil
+	case <-ctx.Done():
+		return Event[K, V]{}, ctx.Err()
+	}
+}
@@ -0,0 +1,168 @@
+package maps
+
+import (
+	"cmp"
+	"container/heap"
+	"iter"
+	"reflect"
+	"slices"
+)
+
+// sortIfOrdered sorts a slice in place, and returns true, if the underlying type of T is ordered
+func sortIfOrdered[T any](items []T) bool {
+	compare := orderedCompare[T]()
+	if compare == nil {
+		return false
+	}
+	slices.SortFunc(items, compare)
+	return true
+}
+
+// orderedCompare returns a func which compares values of T, or nil if the underlying type of T is not ordered
+func orderedCompare[T any]() func(a, b T) int {
+	var compare func(a, b reflect.Value) int
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Int(), b.Int()) }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Uint(), b.Uint()) }
+	case reflect.Float32, reflect.Float64:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.Float(), b.Float()) }
+	case reflect.String:
+		compare = func(a, b reflect.Value) int { return cmp.Compare(a.String(), b.String()) }
+	default:
+		return nil
+	}
+	return func(a, b T) int { return compare(reflect.ValueOf(a), reflect.ValueOf(b)) }
+}
+
+// leastN returns the n least values of seq, in ascending order, without sorting every value
+func leastN[T any](seq iter.Seq[T], n int, compare func(a, b T) int) []T {
+	if n <= 0 {
+		return nil
+	}
+	h := &sliceHeap[T]{compare: compare}
+	for v := range seq {
+		if len(h.items) < n {
+			heap.Push(h, v)
+		} else if compare(v, h.items[0]) < 0 {
+			h.items[0] = v
+			heap.Fix(h, 0)
+		}
+	}
+	items := make([]T, len(h.items))
+	for i := len(items) - 1; i >= 0; i-- {
+		items[i] = heap.Pop(h).(T)
+	}
+	return items
+}
+
+// sliceHeap is a heap.Interface which keeps the greatest value at the root
+type sliceHeap[T any] struct {
+	items   []T
+	compare func(a, b T) int
+}
+
+func (h *sliceHeap[T]) Len() int           { return len(h.items) }
+func (h *sliceHeap[T]) Less(i, j int) bool { return h.compare(h.items[j], h.items[i]) < 0 }
+func (h *sliceHeap[T]) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *sliceHeap[T]) Push(x any)         { h.items = append(h.items, x.(T)) }
+func (h *sliceHeap[T]) Pop() any {
+	v := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return v
+}
+
+// SortedKeys returns the keys of a map in ascending order
+func SortedKeys[M ~map[K]V, K cmp.Ordered, V any](m M) []K {
+	keys := Keys(m)
+	slices.Sort(keys)
+	return keys
+}
+
+// EachSorted calls a function for every entry of a map, in ascending key order
+func EachSorted[M ~map[K]V, K cmp.Ordered, V any](m M, f func(K, V)) {
+	for _, k := range SortedKeys(m) {
+		f(k, m[k])
+	}
+}
+
+// SortByValue returns the entries of a map, ordered by value
+func SortByValue[M ~map[K]V, K comparable, V any](m M, less func(a, b V) bool) []Entry[K, V] {
+	entries := ToEntries(m)
+	slices.SortFunc(entries, func(a, b Entry[K, V]) int {
+		if less(a.Value, b.Value) {
+			return -1
+		} else if less(b.Value, a.Value) {
+			return 1
+		}
+		return 0
+	})
+	return entries
+}
+
+// TopN returns the first n entries of a map, ordered by value, without sorting the whole map
+//
+// To find the greatest values, less should report whether a is greater than b
+func TopN[M ~map[K]V, K comparable, V any](m M, n int, less func(a, b V) bool) []Entry[K, V] {
+	if n <= 0 {
+		return nil
+	}
+	h := &entryHeap[K, V]{less: less}
+	for k, v := range m {
+		if len(h.entries) < n {
+			heap.Push(h, Entry[K, V]{Key: k, Value: v})
+		} else if less(v, h.entries[0].Value) {
+			h.entries[0] = Entry[K, V]{Key: k, Value: v}
+			heap.Fix(h, 0)
+		}
+	}
+	entries := make([]Entry[K, V], len(h.entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		entries[i] = heap.Pop(h).(Entry[K, V])
+	}
+	return entries
+}
+
+// entryHeap is a heap.Interface which keeps the last entry, ordered by value, at the root
+type entryHeap[K comparable, V any] struct {
+	entries []Entry[K, V]
+	less    func(a, b V) bool
+}
+
+func (h *entryHeap[K, V]) Len() int           { return len(h.entries) }
+func (h *entryHeap[K, V]) Less(i, j int) bool { return h.less(h.entries[j].Value, h.entries[i].Value) }
+func (h *entryHeap[K, V]) Swap(i, j int)      { h.entries[i], h.entries[j] = h.entries[j], h.entries[i] }
+func (h *entryHeap[K, V]) Push(x any)         { h.entries = append(h.entries, x.(Entry[K, V])) }
+func (h *entryHeap[K, V]) Pop() any {
+	e := h.entries[len(h.entries)-1]
+	h.entries = h.entries[:len(h.entries)-1]
+	return e
+}
+
+// MinBy returns the entry of a map with the least value, and false if the map is empty
+func MinBy[M ~map[K]V, K comparable, V any](m M, less func(a, b V) bool) (key K, val V, ok bool) {
+	for k, v := range m {
+		if !ok || less(v, val) {
+			key, val, ok = k, v, true
+		}
+	}
+	return
+}
+
+// MaxBy returns the entry of a map with the greatest value, and false if the map is empty
+func MaxBy[M ~map[K]V, K comparable, V any](m M, less func(a, b V) bool) (key K, val V, ok bool) {
+	for k, v := range m {
+		if !ok || less(val, v) {
+			key, val, ok = k, v, true
+		}
+	}
+	return
+}
+
+// SortedUniqueValues returns the distinct values of a map in ascending order
+func SortedUniqueValues[M ~map[K]V, K comparable, V cmp.Ordered](m M) []V {
+	values := UniqueValues(m)
+	slices.Sort(values)
+	return values
+}
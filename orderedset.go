@@ -0,0 +1,76 @@
+package maps
+
+import (
+	"container/list"
+	"iter"
+)
+
+// OrderedSet is a Set which keeps values in insertion order
+type OrderedSet[T comparable] struct {
+	index map[T]*list.Element
+	order *list.List
+}
+
+// NewOrderedSet creates an empty *OrderedSet[T]
+func NewOrderedSet[T comparable]() *OrderedSet[T] {
+	return &OrderedSet[T]{index: make(map[T]*list.Element), order: list.New()}
+}
+
+// Has checks value is in OrderedSet
+func (s *OrderedSet[T]) Has(t T) bool {
+	_, ok := s.index[t]
+	return ok
+}
+
+// Add stores a value at the end, if it is not already present
+func (s *OrderedSet[T]) Add(t T) {
+	if _, ok := s.index[t]; !ok {
+		s.index[t] = s.order.PushBack(t)
+	}
+}
+
+// Remove deletes a value
+func (s *OrderedSet[T]) Remove(t T) {
+	if e, ok := s.index[t]; ok {
+		s.order.Remove(e)
+		delete(s.index, t)
+	}
+}
+
+// Delete deletes items
+func (s *OrderedSet[T]) Delete(items ...T) {
+	for _, t := range items {
+		s.Remove(t)
+	}
+}
+
+// Size returns the number of items
+func (s *OrderedSet[T]) Size() int { return len(s.index) }
+
+// Slice returns the items as []T, in insertion order
+func (s *OrderedSet[T]) Slice() []T {
+	i, slice := 0, make([]T, len(s.index))
+	for e := s.order.Front(); e != nil; e = e.Next() {
+		slice[i] = e.Value.(T)
+		i++
+	}
+	return slice
+}
+
+// Each calls a function once for every value, in insertion order
+func (s *OrderedSet[T]) Each(f func(v T)) {
+	for e := s.order.Front(); e != nil; e = e.Next() {
+		f(e.Value.(T))
+	}
+}
+
+// All returns an iterator over the values, in insertion order
+func (s *OrderedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := s.order.Front(); e != nil; e = e.Next() {
+			if !yield(e.Value.(T)) {
+				return
+			}
+		}
+	}
+}
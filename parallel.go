@@ -0,0 +1,58 @@
+package maps
+
+import (
+	"context"
+	"sync"
+)
+
+// ParallelEach calls a function for every entry of a map, from a pool of workers, and waits for every call to return
+func ParallelEach[M ~map[K]V, K comparable, V any](m M, workers int, f func(K, V)) {
+	ParallelEachCtx(context.Background(), m, workers, func(_ context.Context, k K, v V) error {
+		f(k, v)
+		return nil
+	})
+}
+
+// ParallelEachCtx calls a function for every entry of a map, from a pool of workers, and waits for every call to
+// return
+//
+// When f returns an error, or ctx is done, no more entries are scheduled, ctx passed to f is cancelled, and the
+// first error is returned
+func ParallelEachCtx[M ~map[K]V, K comparable, V any](ctx context.Context, m M, workers int, f func(ctx context.Context, k K, v V) error) error {
+	workers = max(1, min(workers, len(m)))
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	entries := make(chan Entry[K, V])
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range entries {
+				if ctx.Err() != nil {
+					continue
+				} else if err := f(ctx, e.Key, e.Value); err != nil {
+					cancel(err)
+				}
+			}
+		}()
+	}
+schedule:
+	for k, v := range m {
+		// select picks at random when both cases are ready, so check ctx first
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case entries <- Entry[K, V]{Key: k, Value: v}:
+		case <-ctx.Done():
+			break schedule
+		}
+	}
+	close(entries)
+	wg.Wait()
+	if ctx.Err() != nil {
+		return context.Cause(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package maps
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParallelEachCtxStopsAfterError(t *testing.T) {
+	m := make(map[int]int)
+	for i := range 1000 {
+		m[i] = i
+	}
+	fail := errors.New("fail")
+	var calls atomic.Int32
+	err := ParallelEachCtx(context.Background(), m, 1, func(context.Context, int, int) error {
+		calls.Add(1)
+		return fail
+	})
+	if err != fail || calls.Load() != 1 {
+		t.Fatalf("err = %v, calls = %d", err, calls.Load())
+	}
+}
@@ -0,0 +1,33 @@
+package maps
+
+import "sync"
+
+// queue is an unbounded FIFO, which signals when items are pushed
+type queue[T any] struct {
+	mu     sync.Mutex
+	items  []T
+	signal chan struct{}
+}
+
+func newQueue[T any]() *queue[T] {
+	return &queue[T]{signal: make(chan struct{}, 1)}
+}
+
+func (q *queue[T]) push(items ...T) {
+	q.mu.Lock()
+	q.items = append(q.items, items...)
+	q.mu.Unlock()
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// take removes and returns every item
+func (q *queue[T]) take() []T {
+	q.mu.Lock()
+	items := q.items
+	q.items = nil
+	q.mu.Unlock()
+	return items
+}
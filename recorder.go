@@ -0,0 +1,89 @@
+package maps
+
+import (
+	"sync"
+	"time"
+)
+
+// Recorder is an Observer which records every Event, for tests
+type Recorder[K comparable, V any] struct {
+	mu     sync.Mutex
+	events []Event[K, V]
+	next   int
+	signal chan struct{}
+}
+
+// NewRecorder creates an empty *Recorder[K, V]
+func NewRecorder[K comparable, V any]() *Recorder[K, V] {
+	return &Recorder[K, V]{signal: make(chan struct{})}
+}
+
+// Observe implements Observer
+func (r *Recorder[K, V]) Observe(id K, new, old V) {
+	observeSingle(r, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (r *Recorder[K, V]) ObserveBatch(events []Event[K, V]) {
+	r.mu.Lock()
+	r.events = append(r.events, events...)
+	close(r.signal)
+	r.signal = make(chan struct{})
+	r.mu.Unlock()
+}
+
+// Events returns a copy of every recorded Event, in order
+func (r *Recorder[K, V]) Events() []Event[K, V] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Event[K, V](nil), r.events...)
+}
+
+// EventsForKey returns a copy of every recorded Event for a key, in order
+func (r *Recorder[K, V]) EventsForKey(key K) []Event[K, V] {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var events []Event[K, V]
+	for _, e := range r.events {
+		if e.Key == key {
+			events = append(events, e)
+		}
+	}
+	return events
+}
+
+// Len returns the number of recorded events
+func (r *Recorder[K, V]) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+// AwaitEvent returns the recorded Event after the last Event returned by AwaitEvent, waiting up to timeout for it
+func (r *Recorder[K, V]) AwaitEvent(timeout time.Duration) (Event[K, V], bool) {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for {
+		r.mu.Lock()
+		if r.next < len(r.events) {
+			e := r.events[r.next]
+			r.next++
+			r.mu.Unlock()
+			return e, true
+		}
+		signal := r.signal
+		r.mu.Unlock()
+		select {
+		case <-signal:
+		case <-deadline.C:
+			return Event[K, V]{}, false
+		}
+	}
+}
+
+// Reset discards every recorded Event
+func (r *Recorder[K, V]) Reset() {
+	r.mu.Lock()
+	r.events, r.next = nil, 0
+	r.mu.Unlock()
+}
@@ -0,0 +1,98 @@
+package maps
+
+// RenameKey moves the value for old to new, and returns true, unless old is not present or new is already present
+func RenameKey[M ~map[K]V, K comparable, V any](m M, old, new K) bool {
+	v, ok := m[old]
+	if !ok {
+		return false
+	} else if _, ok = m[new]; ok {
+		return false
+	}
+	delete(m, old)
+	m[new] = v
+	return true
+}
+
+// RemapKeys returns a map with every key found in alias replaced by its alias, and every other key kept
+//
+// Renames happen together, so alias may swap keys. If 2 entries produce the same key, RemapKeys returns an error
+// wrapping ErrDuplicateKey
+func RemapKeys[M ~map[K]V, K comparable, V any](m M, alias map[K]K) (M, error) {
+	r, err := MapKeys(m, func(k K, _ V) K {
+		if a, ok := alias[k]; ok {
+			return a
+		}
+		return k
+	}, nil)
+	return M(r), err
+}
+
+// RenameKey calls RenameKey inside the RWMutex write lock state
+func (s *Sync[K, V]) RenameKey(old, new K) bool {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	return RenameKey(s.data, old, new)
+}
+
+// RemapKeys calls RemapKeys inside the RWMutex write lock state, and keeps the result, unless there is an error
+func (s *Sync[K, V]) RemapKeys(alias map[K]K) error {
+	s.rw.Lock()
+	defer s.rw.Unlock()
+	r, err := RemapKeys(s.data, alias)
+	if err != nil {
+		return err
+	}
+	s.data = r
+	return nil
+}
+
+// RenameKey moves the value for old to new, with an EventDelete and an EventCreate, unless old is not present or new
+// is already present
+//
+// returns whether the key was renamed, and the error from the first BeforeSetFunc or CheckedObserver to reject the
+// change, in which case nothing is changed
+func (o *Observable[K, V]) RenameKey(old, new K) (renamed bool, err error) {
+	err = o.atomic(func() error {
+		v, ok := o.sync.data[old]
+		if !ok {
+			return nil
+		} else if _, ok = o.sync.data[new]; ok {
+			return nil
+		}
+		renamed = true
+		return o.applyPatch(Patch[K, V]{Add: map[K]V{new: v}, Delete: NewSetOf(old)})
+	})
+	return renamed && err == nil, err
+}
+
+// RemapKeys calls RemapKeys inside the RWMutex write lock state, and keeps the result, unless there is an error,
+// notifying observers once with every change
+//
+// returns an error wrapping ErrDuplicateKey, or the error from the first BeforeSetFunc or CheckedObserver to reject
+// a change, in which case nothing is changed
+func (o *Observable[K, V]) RemapKeys(alias map[K]K) error {
+	return o.atomic(func() error { return o.remapKeys(alias) })
+}
+
+func (o *Observable[K, V]) remapKeys(alias map[K]K) error {
+	r, err := RemapKeys(o.sync.data, alias)
+	if err != nil {
+		return err
+	}
+	p := Patch[K, V]{
+		Add:    make(map[K]V),
+		Update: make(map[K]V),
+		Delete: DiffKeys(o.sync.data, r),
+	}
+	for k, a := range alias {
+		v, ok := o.sync.data[k]
+		if !ok || k == a {
+			continue
+		} else if _, ok = o.sync.data[a]; ok {
+			p.Update[a] = v
+		} else {
+			p.Add[a] = v
+		}
+	}
+	return o.applyPatch(p)
+}
@@ -0,0 +1,34 @@
+package maps
+
+import "math/rand/v2"
+
+// Sample returns a map with n entries chosen uniformly at random, or a clone if the map has n or fewer entries
+func Sample[M ~map[K]V, K comparable, V any](m M, n int) M {
+	if m == nil {
+		return nil
+	}
+	n = max(n, 0)
+	entries := make([]Entry[K, V], 0, min(n, len(m)))
+	i := 0
+	for k, v := range m {
+		if i < n {
+			entries = append(entries, Entry[K, V]{Key: k, Value: v})
+		} else if j := rand.IntN(i + 1); j < n {
+			entries[j] = Entry[K, V]{Key: k, Value: v}
+		}
+		i++
+	}
+	return M(FromEntries(entries))
+}
+
+// RandomEntry returns an entry chosen uniformly at random, and false if the map is empty
+func RandomEntry[M ~map[K]V, K comparable, V any](m M) (key K, val V, ok bool) {
+	i := 0
+	for k, v := range m {
+		if rand.IntN(i+1) == 0 {
+			key, val, ok = k, v, true
+		}
+		i++
+	}
+	return
+}
@@ -1,11 +1,29 @@
 package maps
 
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // Set is a map[T]struct{}
 type Set[T comparable] map[T]struct{}
 
 // NewSet creates an empty Set[T]
 func NewSet[T comparable]() Set[T] { return Set[T](make(map[T]struct{})) }
 
+// NewSetOf creates a Set[T] with items
+func NewSetOf[T comparable](items ...T) Set[T] { return SetFromSlice(items) }
+
+// SetFromSlice creates a Set[T] with every value in a slice
+func SetFromSlice[S ~[]T, T comparable](items S) Set[T] {
+	s := make(Set[T], len(items))
+	for _, t := range items {
+		s[t] = struct{}{}
+	}
+	return s
+}
+
 // Has checks value is in Set
 func (s Set[T]) Has(t T) bool {
 	_, ok := s[t]
@@ -18,6 +36,19 @@ func (s Set[T]) Add(t T) { s[t] = struct{}{} }
 // Remove deletes a value
 func (s Set[T]) Remove(t T) { delete(s, t) }
 
+// AddSlice stores every value in a slice
+func (s Set[T]) AddSlice(items []T) {
+	for _, t := range items {
+		s[t] = struct{}{}
+	}
+}
+
+// AddSet stores every value in other
+func (s Set[T]) AddSet(other Set[T]) { s.UnionWith(other) }
+
+// RemoveSlice deletes every value in a slice
+func (s Set[T]) RemoveSlice(items []T) { s.Delete(items...) }
+
 // Slice returns this Set[T] as []T
 func (s Set[T]) Slice() []T {
 	i, slice := 0, make([]T, len(s))
@@ -41,3 +72,263 @@ func (s Set[T]) Delete(items ...T) {
 		delete(s, t)
 	}
 }
+
+// Clone returns a shallow clone of the Set, or nil if the Set is nil
+func (s Set[T]) Clone() Set[T] { return Clone(s) }
+
+// Clear deletes every value
+func (s Set[T]) Clear() { clear(s) }
+
+// Union returns a new Set with every value in either Set
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	r := make(Set[T], len(s)+len(other))
+	for v := range s {
+		r[v] = struct{}{}
+	}
+	for v := range other {
+		r[v] = struct{}{}
+	}
+	return r
+}
+
+// Intersect returns a new Set with every value in both Sets
+func (s Set[T]) Intersect(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	r := make(Set[T])
+	for v := range small {
+		if large.Has(v) {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Difference returns a new Set with every value in this Set and not in other
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	r := make(Set[T])
+	for v := range s {
+		if !other.Has(v) {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// SymmetricDifference returns a new Set with every value in exactly one of the Sets
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	r := make(Set[T])
+	for v := range s {
+		if !other.Has(v) {
+			r[v] = struct{}{}
+		}
+	}
+	for v := range other {
+		if !s.Has(v) {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// UnionWith adds every value in other
+func (s Set[T]) UnionWith(other Set[T]) {
+	for v := range other {
+		s[v] = struct{}{}
+	}
+}
+
+// IntersectWith deletes every value not in other
+func (s Set[T]) IntersectWith(other Set[T]) {
+	for v := range s {
+		if !other.Has(v) {
+			delete(s, v)
+		}
+	}
+}
+
+// RetainOnly deletes every value not in other, and is the same as IntersectWith
+func (s Set[T]) RetainOnly(other Set[T]) { s.IntersectWith(other) }
+
+// SubtractWith deletes every value in other, iterating the smaller Set
+func (s Set[T]) SubtractWith(other Set[T]) {
+	if len(other) <= len(s) {
+		for v := range other {
+			delete(s, v)
+		}
+		return
+	}
+	for v := range s {
+		if other.Has(v) {
+			delete(s, v)
+		}
+	}
+}
+
+// Equal returns whether both Sets have the same values
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s) == len(other) && s.IsSubset(other)
+}
+
+// IsSubset returns whether every value in this Set is in other
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s) > len(other) {
+		return false
+	}
+	for v := range s {
+		if !other.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns whether every value in other is in this Set
+func (s Set[T]) IsSuperset(other Set[T]) bool { return other.IsSubset(s) }
+
+// IsDisjoint returns whether no value is in both Sets
+func (s Set[T]) IsDisjoint(other Set[T]) bool {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	for v := range small {
+		if large.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects returns whether any value is in both Sets
+func (s Set[T]) Intersects(other Set[T]) bool { return !s.IsDisjoint(other) }
+
+// IntersectionSize returns the number of values in both Sets, iterating the smaller Set
+func (s Set[T]) IntersectionSize(other Set[T]) int {
+	small, large := s, other
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	n := 0
+	for v := range small {
+		if large.Has(v) {
+			n++
+		}
+	}
+	return n
+}
+
+// MarshalJSON implements json.Marshaler, encoding as an array, which is sorted if T is ordered
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	slice := s.Slice()
+	sortIfOrdered(slice)
+	return json.Marshal(slice)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, adding every value in an array
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var slice []T
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	if *s == nil {
+		*s = make(Set[T], len(slice))
+	}
+	for _, t := range slice {
+		(*s)[t] = struct{}{}
+	}
+	return nil
+}
+
+// Filter returns a new Set with every value where test returns true
+func (s Set[T]) Filter(test func(T) bool) Set[T] {
+	r := make(Set[T])
+	for v := range s {
+		if test(v) {
+			r[v] = struct{}{}
+		}
+	}
+	return r
+}
+
+// Any returns whether test returns true for any value
+func (s Set[T]) Any(test func(T) bool) bool {
+	for v := range s {
+		if test(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// Every returns whether test returns true for every value
+//
+// Every is the predicate counterpart of Any, because All is the iterator
+func (s Set[T]) Every(test func(T) bool) bool {
+	for v := range s {
+		if !test(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// None returns whether test returns false for every value
+func (s Set[T]) None(test func(T) bool) bool { return !s.Any(test) }
+
+// ReduceSet returns an accumulation of a Set using an accumulation func
+func ReduceSet[T comparable, A any](s Set[T], a A, f func(A, T) A) A {
+	for v := range s {
+		a = f(a, v)
+	}
+	return a
+}
+
+// MapSet returns a new Set with the result of f for every value
+func MapSet[T comparable, U comparable](s Set[T], f func(T) U) Set[U] {
+	r := make(Set[U], len(s))
+	for v := range s {
+		r[f(v)] = struct{}{}
+	}
+	return r
+}
+
+// String implements fmt.Stringer, formatting up to 20 values, see StringN
+func (s Set[T]) String() string { return s.StringN(20) }
+
+// StringN formats the Set like {a, b, c}, sorted, with up to n values and a count of the values omitted
+//
+// Only the n least values are selected and formatted, when the underlying type of T is ordered; otherwise every
+// value is formatted, to sort by the text
+func (s Set[T]) StringN(n int) string {
+	if n < 0 || n > len(s) {
+		n = len(s)
+	}
+	var items []string
+	if compare := orderedCompare[T](); compare != nil {
+		for _, v := range leastN(s.All(), n, compare) {
+			items = append(items, fmt.Sprint(v))
+		}
+	} else {
+		items = leastN(func(yield func(string) bool) {
+			for v := range s {
+				if !yield(fmt.Sprint(v)) {
+					return
+				}
+			}
+		}, n, strings.Compare)
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	sb.WriteString(strings.Join(items, ", "))
+	if len(s) > n {
+		if n > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "... %d more", len(s)-n)
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
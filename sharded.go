@@ -0,0 +1,117 @@
+package maps
+
+// Sharded is a generic observable map, which stripes keys across many Observables to allow concurrent writes
+//
+// Changes to the same key are delivered in order, but observers may be called concurrently for keys in different
+// shards
+type Sharded[K comparable, V any] struct {
+	shards []*Observable[K, V]
+	hash   func(K) uint64
+}
+
+// NewSharded creates an empty *Sharded[K, V] with n shards, which uses hash to assign keys to shards
+//
+// NewSharded panics if n is less than 1
+func NewSharded[K comparable, V any](n int, hash func(K) uint64) *Sharded[K, V] {
+	if n < 1 {
+		panic("maps: Sharded must have at least 1 shard")
+	}
+	shards := make([]*Observable[K, V], n)
+	for i := range shards {
+		shards[i] = NewObservable[K, V]()
+	}
+	return &Sharded[K, V]{shards: shards, hash: hash}
+}
+
+// Shard returns the Observable which holds a key
+func (s *Sharded[K, V]) Shard(key K) *Observable[K, V] {
+	return s.shards[s.hash(key)%uint64(len(s.shards))]
+}
+
+// Keys returns the keys
+func (s *Sharded[K, V]) Keys() []K {
+	keys := make([]K, 0, s.Size())
+	for _, o := range s.shards {
+		keys = append(keys, o.Keys()...)
+	}
+	return keys
+}
+
+// Values returns the values
+func (s *Sharded[K, V]) Values() []V {
+	values := make([]V, 0, s.Size())
+	for _, o := range s.shards {
+		values = append(values, o.Values()...)
+	}
+	return values
+}
+
+// Size returns the number of items, reading each shard in turn inside its RWMutex read lock state
+func (s *Sharded[K, V]) Size() int {
+	size := 0
+	for _, o := range s.shards {
+		o.sync.rw.RLock()
+		size += len(o.sync.data)
+		o.sync.rw.RUnlock()
+	}
+	return size
+}
+
+// Get returns the value for a key, inside the RWMutex read lock state of its shard
+func (s *Sharded[K, V]) Get(key K) V {
+	val, _ := s.Shard(key).GetOk(key)
+	return val
+}
+
+// GetOk returns the value for a key, and whether the key is present
+func (s *Sharded[K, V]) GetOk(key K) (V, bool) { return s.Shard(key).GetOk(key) }
+
+// Set changes the value for a key
+func (s *Sharded[K, V]) Set(key K, val V) error { return s.Shard(key).Set(key, val) }
+
+// Delete deletes keys
+//
+// returns the error from the first CheckedObserver to reject a change, which stops deleting; keys in other shards
+// which were already deleted stay deleted
+func (s *Sharded[K, V]) Delete(keys ...K) error {
+	for _, key := range keys {
+		if err := s.Shard(key).Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Each calls a function, once for every value, inside the mutex lock state of each shard in turn
+func (s *Sharded[K, V]) Each(f func(K, V)) {
+	for _, o := range s.shards {
+		o.Each(f)
+	}
+}
+
+// Observe adds an observer to every shard
+func (s *Sharded[K, V]) Observe(f Observer[K, V]) {
+	for _, o := range s.shards {
+		o.Observe(f)
+	}
+}
+
+// Subscribe adds an observer to every shard, and returns a func to remove it
+func (s *Sharded[K, V]) Subscribe(f Observer[K, V]) (cancel func()) {
+	cancels := make([]func(), len(s.shards))
+	for i, o := range s.shards {
+		cancels[i] = o.Subscribe(f)
+	}
+	return func() {
+		for _, cancel := range cancels {
+			cancel()
+		}
+	}
+}
+
+// BeforeSet adds a hook to every shard
+func (s *Sharded[K, V]) BeforeSet(f BeforeSetFunc[K, V]) {
+	for _, o := range s.shards {
+		o.BeforeSet(f)
+	}
+}
@@ -0,0 +1,29 @@
+package maps
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestShardedConcurrentGetAndSet(t *testing.T) {
+	s := NewSharded[int, int](4, func(k int) uint64 { return uint64(k) })
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			s.Set(i%8, i)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := range 1000 {
+			s.Get(i % 8)
+			s.Size()
+		}
+	}()
+	wg.Wait()
+	if s.Size() != 8 {
+		t.Fatalf("Size = %d", s.Size())
+	}
+}
@@ -0,0 +1,111 @@
+package maps
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Sink is an interface for writing batches of events to another system, such as a message queue or database
+//
+// An error means the batch was not accepted
+type Sink[K comparable, V any] interface {
+	Write(ctx context.Context, events []Event[K, V]) error
+}
+
+// SinkFunc is a func type that implements Sink
+type SinkFunc[K comparable, V any] func(ctx context.Context, events []Event[K, V]) error
+
+func (f SinkFunc[K, V]) Write(ctx context.Context, events []Event[K, V]) error { return f(ctx, events) }
+
+// SinkOptions configures Observable.AttachSink
+type SinkOptions struct {
+	// Buffer is the number of batches queued before writers block, 64 by default
+	Buffer int
+	// MaxBatch is the most events in each Write, unlimited by default
+	MaxBatch int
+	// Retries is the number of times a failed Write is retried
+	Retries int
+	// Backoff is the wait before the first retry, which doubles after each retry
+	Backoff time.Duration
+	// OnError is called with the last error when a batch is dropped
+	OnError func(err error, dropped int)
+}
+
+// AttachSink delivers every later Event to sink, from a separate goroutine, until detach is called
+//
+// Events are buffered and combined into batches. When the buffer is full, writers to the Observable wait. detach
+// writes the buffered events before returning
+func (o *Observable[K, V]) AttachSink(ctx context.Context, sink Sink[K, V], opts SinkOptions) (detach func()) {
+	if opts.Buffer < 1 {
+		opts.Buffer = 64
+	}
+	ch, done, exited := make(chan []Event[K, V], opts.Buffer), make(chan struct{}), make(chan struct{})
+	cancel := o.Subscribe(BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		select {
+		case ch <- events:
+		case <-done:
+		}
+	}))
+	go func() {
+		defer close(exited)
+		for {
+			var events []Event[K, V]
+			select {
+			case events = <-ch:
+			case <-done:
+				writeSink(ctx, sink, opts, drainSink(ch, nil))
+				return
+			}
+			writeSink(ctx, sink, opts, drainSink(ch, events))
+		}
+	}()
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			cancel()
+			close(done)
+			<-exited
+		})
+	}
+}
+
+// drainSink appends every buffered batch without waiting
+//
+// events is shared with every other observer, so it is clipped to copy on the first append
+func drainSink[K comparable, V any](ch chan []Event[K, V], events []Event[K, V]) []Event[K, V] {
+	events = slices.Clip(events)
+	for {
+		select {
+		case batch := <-ch:
+			events = append(events, batch...)
+		default:
+			return events
+		}
+	}
+}
+
+func writeSink[K comparable, V any](ctx context.Context, sink Sink[K, V], opts SinkOptions, events []Event[K, V]) {
+	for len(events) > 0 {
+		batch := events
+		if opts.MaxBatch > 0 && len(batch) > opts.MaxBatch {
+			batch = batch[:opts.MaxBatch]
+		}
+		events = events[len(batch):]
+		err := sink.Write(ctx, batch)
+		for i, wait := 0, opts.Backoff; err != nil && i < opts.Retries; i, wait = i+1, wait*2 {
+			select {
+			case <-time.After(wait):
+			case <-ctx.Done():
+			}
+			if ctx.Err() != nil {
+				break
+			}
+			err = sink.Write(ctx, batch)
+		}
+		if err != nil && opts.OnError != nil {
+			opts.OnError(err, len(batch))
+		}
+	}
+}
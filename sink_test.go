@@ -0,0 +1,45 @@
+package maps
+
+import (
+	"context"
+	"sync"
+	"testing"
+)
+
+func TestAttachSinksDoNotShareBatches(t *testing.T) {
+	o := NewObservable[string, int]()
+	var mu sync.Mutex
+	var written [2][]Event[string, int]
+	var detach [2]func()
+	for i := range detach {
+		detach[i] = o.AttachSink(context.Background(), SinkFunc[string, int](func(_ context.Context, events []Event[string, int]) error {
+			mu.Lock()
+			written[i] = append(written[i], events...)
+			mu.Unlock()
+			return nil
+		}), SinkOptions{})
+	}
+
+	for n := range 50 {
+		o.Txn(func(tx *Tx[string, int]) error {
+			tx.Set("a", n)
+			tx.Set("b", n)
+			return tx.Set("c", n)
+		})
+		o.Set("d", n)
+	}
+	for _, f := range detach {
+		f()
+	}
+
+	for i, events := range written {
+		if len(events) != 200 {
+			t.Fatalf("sink %d wrote %d events", i, len(events))
+		}
+		for j, e := range events {
+			if e.Seq != uint64(j+1) {
+				t.Fatalf("sink %d event %d = %v", i, j, e)
+			}
+		}
+	}
+}
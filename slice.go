@@ -0,0 +1,90 @@
+package maps
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLengthMismatch is returned when parallel slices have different lengths
+var ErrLengthMismatch = errors.New("maps: length mismatch")
+
+// GroupBy returns a map from each key to the values in a slice with that key, in slice order
+func GroupBy[S ~[]V, G comparable, V any](s S, key func(V) G) map[G][]V {
+	r := make(map[G][]V)
+	for _, v := range s {
+		g := key(v)
+		r[g] = append(r[g], v)
+	}
+	return r
+}
+
+// KeyBy returns a map from the key of each value in a slice to that value, where later values overwrite earlier values
+func KeyBy[S ~[]V, K comparable, V any](s S, key func(V) K) map[K]V {
+	r := make(map[K]V, len(s))
+	for _, v := range s {
+		r[key(v)] = v
+	}
+	return r
+}
+
+// KeyByStrict returns a map from the key of each value in a slice to that value
+//
+// When 2 values produce the same key, KeyByStrict returns an error wrapping ErrDuplicateKey
+func KeyByStrict[S ~[]V, K comparable, V any](s S, key func(V) K) (map[K]V, error) {
+	r := make(map[K]V, len(s))
+	for _, v := range s {
+		k := key(v)
+		if _, ok := r[k]; ok {
+			return nil, fmt.Errorf("%w: %v", ErrDuplicateKey, k)
+		}
+		r[k] = v
+	}
+	return r, nil
+}
+
+// CountBy returns a map from each class to the number of values in a slice in that class
+func CountBy[S ~[]V, K comparable, V any](s S, classify func(V) K) map[K]int {
+	r := make(map[K]int)
+	for _, v := range s {
+		r[classify(v)]++
+	}
+	return r
+}
+
+// FromSlices returns a map from each key to the value at the same index, where later keys overwrite earlier keys
+//
+// If the slices have different lengths, FromSlices returns an error wrapping ErrLengthMismatch
+func FromSlices[SK ~[]K, SV ~[]V, K comparable, V any](keys SK, values SV) (map[K]V, error) {
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("%w: %d keys, %d values", ErrLengthMismatch, len(keys), len(values))
+	}
+	r := make(map[K]V, len(keys))
+	for i, k := range keys {
+		r[k] = values[i]
+	}
+	return r, nil
+}
+
+// FromSliceBy returns a map with the key and value derived from each item in a slice, where later items overwrite
+// earlier items
+func FromSliceBy[S ~[]T, T any, K comparable, V any](s S, key func(T) K, val func(T) V) map[K]V {
+	r := make(map[K]V, len(s))
+	for _, t := range s {
+		r[key(t)] = val(t)
+	}
+	return r
+}
+
+// FromKeys returns a map with every key in a slice, with the value v
+func FromKeys[S ~[]K, K comparable, V any](keys S, v V) map[K]V {
+	return FromKeysFunc(keys, func(K) V { return v })
+}
+
+// FromKeysFunc returns a map with every key in a slice, with the result of f
+func FromKeysFunc[S ~[]K, K comparable, V any](keys S, f func(K) V) map[K]V {
+	r := make(map[K]V, len(keys))
+	for _, k := range keys {
+		r[k] = f(k)
+	}
+	return r
+}
@@ -0,0 +1,57 @@
+package maps
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogObserver is an Observer which logs every Event
+type SlogObserver[K comparable, V any] struct {
+	logger *slog.Logger
+	level  slog.Level
+	redact func(K, V) any
+}
+
+// NewSlogObserver creates a *SlogObserver[K, V] which logs to logger at level
+func NewSlogObserver[K comparable, V any](logger *slog.Logger, level slog.Level) *SlogObserver[K, V] {
+	return &SlogObserver[K, V]{logger: logger, level: level}
+}
+
+// Redact sets a func which replaces every value before it is logged, and returns the *SlogObserver
+func (s *SlogObserver[K, V]) Redact(f func(key K, val V) any) *SlogObserver[K, V] {
+	s.redact = f
+	return s
+}
+
+// Observe implements Observer
+func (s *SlogObserver[K, V]) Observe(id K, new, old V) {
+	observeSingle(s, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (s *SlogObserver[K, V]) ObserveBatch(events []Event[K, V]) {
+	ctx := context.Background()
+	if !s.logger.Enabled(ctx, s.level) {
+		return
+	}
+	for _, e := range events {
+		attrs := []slog.Attr{slog.String("kind", e.Kind.String()), slog.Any("key", e.Key)}
+		if !e.Kind.IsDelete() {
+			attrs = append(attrs, slog.Any("new", s.value(e.Key, e.New)))
+		}
+		if e.Kind != EventCreate {
+			attrs = append(attrs, slog.Any("old", s.value(e.Key, e.Old)))
+		}
+		if e.Seq > 0 {
+			attrs = append(attrs, slog.Uint64("seq", e.Seq), slog.Time("at", e.Time))
+		}
+		s.logger.LogAttrs(ctx, s.level, "maps event", attrs...)
+	}
+}
+
+func (s *SlogObserver[K, V]) value(key K, val V) any {
+	if s.redact != nil {
+		return s.redact(key, val)
+	}
+	return val
+}
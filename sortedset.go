@@ -0,0 +1,99 @@
+package maps
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// SortedSet is a Set which keeps values in ascending order, backed by a sorted slice
+type SortedSet[T cmp.Ordered] struct {
+	items []T
+}
+
+// NewSortedSet creates a *SortedSet[T] with items
+func NewSortedSet[T cmp.Ordered](items ...T) *SortedSet[T] {
+	s := &SortedSet[T]{items: slices.Clone(items)}
+	slices.Sort(s.items)
+	s.items = slices.Compact(s.items)
+	return s
+}
+
+// Has checks value is in SortedSet
+func (s *SortedSet[T]) Has(t T) bool {
+	_, ok := slices.BinarySearch(s.items, t)
+	return ok
+}
+
+// Add stores a value
+func (s *SortedSet[T]) Add(t T) {
+	if i, ok := slices.BinarySearch(s.items, t); !ok {
+		s.items = slices.Insert(s.items, i, t)
+	}
+}
+
+// Remove deletes a value
+func (s *SortedSet[T]) Remove(t T) {
+	if i, ok := slices.BinarySearch(s.items, t); ok {
+		s.items = slices.Delete(s.items, i, i+1)
+	}
+}
+
+// Delete deletes items
+func (s *SortedSet[T]) Delete(items ...T) {
+	for _, t := range items {
+		s.Remove(t)
+	}
+}
+
+// Size returns the number of items
+func (s *SortedSet[T]) Size() int { return len(s.items) }
+
+// Slice returns the items as []T, in ascending order
+func (s *SortedSet[T]) Slice() []T { return slices.Clone(s.items) }
+
+// Each calls a function once for every value, in ascending order
+func (s *SortedSet[T]) Each(f func(v T)) {
+	for _, v := range s.items {
+		f(v)
+	}
+}
+
+// All returns an iterator over the values, in ascending order
+func (s *SortedSet[T]) All() iter.Seq[T] { return s.iter(s.items) }
+
+// Min returns the least value, and false if the SortedSet is empty
+func (s *SortedSet[T]) Min() (t T, ok bool) {
+	if len(s.items) < 1 {
+		return
+	}
+	return s.items[0], true
+}
+
+// Max returns the greatest value, and false if the SortedSet is empty
+func (s *SortedSet[T]) Max() (t T, ok bool) {
+	if len(s.items) < 1 {
+		return
+	}
+	return s.items[len(s.items)-1], true
+}
+
+// Range returns an iterator over the values from <= v < to, in ascending order
+func (s *SortedSet[T]) Range(from, to T) iter.Seq[T] {
+	i, _ := slices.BinarySearch(s.items, from)
+	j, _ := slices.BinarySearch(s.items, to)
+	if j < i {
+		j = i
+	}
+	return s.iter(s.items[i:j])
+}
+
+func (s *SortedSet[T]) iter(items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,198 @@
+package maps
+
+import (
+	"encoding"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// StructToMap returns a map with the exported fields of a struct, or pointer to struct, named by the struct tag
+//
+// The tag is parsed like encoding/json: "-" skips a field, an empty name uses the field name, and the omitempty
+// option skips zero values. Nested structs, and pointers to structs, become nested maps, and embedded structs
+// without a name are inlined. Values which implement encoding.TextMarshaler are kept as is
+func StructToMap(v any, tag string) (map[string]any, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, nil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("maps: StructToMap of %T", v)
+	}
+	m := make(map[string]any)
+	structToMap(m, rv, tag)
+	return m, nil
+}
+
+func structToMap(m map[string]any, rv reflect.Value, tag string) {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, omitempty, ok := fieldName(f, tag)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if omitempty && fv.IsZero() {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			if sv, ok := structValue(fv); ok {
+				structToMap(m, sv, tag)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue // an unexported embedded struct is only kept when inlined
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if sv, ok := structValue(fv); ok {
+			n := make(map[string]any)
+			structToMap(n, sv, tag)
+			m[name] = n
+		} else if fv.Kind() == reflect.Pointer && fv.IsNil() && isStruct(fv.Type().Elem()) {
+			m[name] = nil
+		} else {
+			m[name] = fv.Interface()
+		}
+	}
+}
+
+// MapToStruct writes the values of a map to the exported fields of the struct which dst points to, named by the
+// struct tag, the inverse of StructToMap
+//
+// Values are assigned when the types match, or are both numeric. Nested maps are written to nested structs, and
+// pointers to structs are allocated as needed
+func MapToStruct(m map[string]any, dst any, tag string) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("maps: MapToStruct into %T", dst)
+	}
+	if err := mapToStruct(m, rv.Elem(), tag); err != nil {
+		return fmt.Errorf("maps: %w", err)
+	}
+	return nil
+}
+
+func mapToStruct(m map[string]any, rv reflect.Value, tag string) error {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, ok := fieldName(f, tag)
+		if !ok {
+			continue
+		}
+		fv := rv.Field(i)
+		if f.Anonymous && name == "" && isStruct(f.Type) {
+			if fv.Kind() == reflect.Pointer {
+				if fv.IsNil() {
+					fv.Set(reflect.New(f.Type.Elem()))
+				}
+				fv = fv.Elem()
+			}
+			if err := mapToStruct(m, fv, tag); err != nil {
+				return err
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		v, ok := m[name]
+		if !ok {
+			continue
+		}
+		if err := assign(fv, v, tag); err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// assign writes v to a struct field
+func assign(fv reflect.Value, v any, tag string) error {
+	if v == nil {
+		fv.SetZero()
+		return nil
+	}
+	if n, ok := v.(map[string]any); ok && isStruct(fv.Type()) {
+		if fv.Kind() == reflect.Pointer {
+			if fv.IsNil() {
+				fv.Set(reflect.New(fv.Type().Elem()))
+			}
+			fv = fv.Elem()
+		}
+		return mapToStruct(n, fv, tag)
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Type().AssignableTo(fv.Type()) {
+		fv.Set(rv)
+	} else if isNumeric(rv.Kind()) && isNumeric(fv.Kind()) {
+		cv := rv.Convert(fv.Type())
+		if cv.Convert(rv.Type()).Interface() != v {
+			return fmt.Errorf("cannot represent %v as %s", v, fv.Type())
+		}
+		fv.Set(cv)
+	} else {
+		return fmt.Errorf("cannot use %T as %s", v, fv.Type())
+	}
+	return nil
+}
+
+// fieldName returns the name and omitempty option from the struct tag, and false if the field is skipped
+func fieldName(f reflect.StructField, tag string) (name string, omitempty bool, ok bool) {
+	value := f.Tag.Get(tag)
+	if value == "-" {
+		return "", false, false
+	}
+	name, opts, _ := strings.Cut(value, ",")
+	if !f.IsExported() && !(f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct) {
+		return "", false, false
+	}
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		omitempty = omitempty || opt == "omitempty"
+	}
+	return name, omitempty, true
+}
+
+// structValue returns the struct which a field holds or points to, unless it implements encoding.TextMarshaler
+func structValue(fv reflect.Value) (reflect.Value, bool) {
+	if !isStruct(fv.Type()) {
+		return reflect.Value{}, false
+	} else if fv.Type().Implements(textMarshaler) || reflect.PointerTo(fv.Type()).Implements(textMarshaler) {
+		return reflect.Value{}, false
+	} else if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() || fv.Type().Elem().Implements(textMarshaler) {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	return fv, true
+}
+
+var textMarshaler = reflect.TypeFor[encoding.TextMarshaler]()
+
+func isStruct(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
+
+func isNumeric(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
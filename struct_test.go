@@ -0,0 +1,22 @@
+package maps
+
+import (
+	"testing"
+	"time"
+)
+
+type embeddedTime struct{ time.Time }
+
+func TestStructToMapSkipsUnexportedTextMarshaler(t *testing.T) {
+	v := struct {
+		embeddedTime
+		X int
+	}{X: 1}
+	m, err := StructToMap(v, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(m) != 1 || m["X"] != 1 {
+		t.Fatalf("m = %v", m)
+	}
+}
@@ -15,6 +15,13 @@ func NewSync[K comparable, V any]() *Sync[K, V] {
 	}
 }
 
+// NewSyncFromKeys creates a *Sync[K, V] with every key in a slice, with the result of f
+func NewSyncFromKeys[K comparable, V any](keys []K, f func(K) V) *Sync[K, V] {
+	return &Sync[K, V]{
+		data: FromKeysFunc(keys, f),
+	}
+}
+
 // Keys returns the keys
 func (s *Sync[K, V]) Keys() []K {
 	s.rw.RLock()
@@ -45,6 +52,59 @@ func (s *Sync[K, V]) Size() int { return len(s.data) }
 // Get returns the value for a key
 func (s *Sync[K, V]) Get(key K) V { return s.data[key] }
 
+// GetOk returns the value for a key, and whether the key is present
+func (s *Sync[K, V]) GetOk(key K) (val V, ok bool) {
+	s.rw.RLock()
+	val, ok = s.data[key]
+	s.rw.RUnlock()
+	return
+}
+
+// LoadOrStore returns the value for a key if present, otherwise stores and returns the given value
+//
+// loaded is true if the value was present
+func (s *Sync[K, V]) LoadOrStore(key K, val V) (actual V, loaded bool) {
+	s.rw.Lock()
+	if actual, loaded = s.data[key]; !loaded {
+		s.data[key], actual = val, val
+	}
+	s.rw.Unlock()
+	return
+}
+
+// Swap changes the value for a key, and returns the previous value
+//
+// loaded is true if the key was present
+func (s *Sync[K, V]) Swap(key K, val V) (previous V, loaded bool) {
+	s.rw.Lock()
+	previous, loaded = s.data[key]
+	s.data[key] = val
+	s.rw.Unlock()
+	return
+}
+
+// GetAndDelete deletes a key, and returns the removed value
+//
+// loaded is true if the key was present
+func (s *Sync[K, V]) GetAndDelete(key K) (val V, loaded bool) {
+	s.rw.Lock()
+	if val, loaded = s.data[key]; loaded {
+		delete(s.data, key)
+	}
+	s.rw.Unlock()
+	return
+}
+
+// CompareAndSwap changes the value for a key, if the key is present and eq reports the current value is equal to old
+func (s *Sync[K, V]) CompareAndSwap(key K, old, new V, eq func(a, b V) bool) (swapped bool) {
+	s.rw.Lock()
+	if cur, ok := s.data[key]; ok && eq(cur, old) {
+		s.data[key], swapped = new, true
+	}
+	s.rw.Unlock()
+	return
+}
+
 // Set changes the value for a key
 func (s *Sync[K, V]) Set(key K, val V) {
 	s.rw.Lock()
@@ -117,6 +177,23 @@ func ReduceSync[K comparable, V any, A any](s *Sync[K, V], a A, f func(A, K, V)
 	return a
 }
 
+// EqualSync returns whether 2 *Sync have the same entries
+func EqualSync[K comparable, V comparable](a, b *Sync[K, V]) bool {
+	return EqualFuncSync(a, b, func(x, y V) bool { return x == y })
+}
+
+// EqualFuncSync returns whether 2 *Sync have the same keys, with values that eq reports are equal
+//
+// a is copied before b is locked, so a and b are never locked together
+func EqualFuncSync[K comparable, V1 any, V2 any](a *Sync[K, V1], b *Sync[K, V2], eq func(V1, V2) bool) bool {
+	a.rw.RLock()
+	snapshot := Clone(a.data)
+	a.rw.RUnlock()
+	b.rw.RLock()
+	defer b.rw.RUnlock()
+	return EqualFunc(snapshot, b.data, eq)
+}
+
 // Delete deletes keys
 func (s *Sync[K, V]) Delete(keys ...K) {
 	s.rw.Lock()
@@ -0,0 +1,98 @@
+package maps
+
+import "sync"
+
+// SyncSet is a generic RWMutex Set
+type SyncSet[T comparable] struct {
+	rw  sync.RWMutex
+	set Set[T]
+}
+
+// NewSyncSet creates an empty *SyncSet[T]
+func NewSyncSet[T comparable]() *SyncSet[T] {
+	return &SyncSet[T]{set: NewSet[T]()}
+}
+
+// Has checks value is in SyncSet
+func (s *SyncSet[T]) Has(t T) bool {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return s.set.Has(t)
+}
+
+// Add stores a value
+func (s *SyncSet[T]) Add(t T) {
+	s.rw.Lock()
+	s.set.Add(t)
+	s.rw.Unlock()
+}
+
+// Remove deletes a value
+func (s *SyncSet[T]) Remove(t T) {
+	s.rw.Lock()
+	s.set.Remove(t)
+	s.rw.Unlock()
+}
+
+// AddSlice stores every value in a slice
+func (s *SyncSet[T]) AddSlice(items []T) {
+	s.rw.Lock()
+	s.set.AddSlice(items)
+	s.rw.Unlock()
+}
+
+// AddSet stores every value in a Set
+func (s *SyncSet[T]) AddSet(other Set[T]) {
+	s.rw.Lock()
+	s.set.AddSet(other)
+	s.rw.Unlock()
+}
+
+// RemoveSlice deletes every value in a slice
+func (s *SyncSet[T]) RemoveSlice(items []T) {
+	s.rw.Lock()
+	s.set.RemoveSlice(items)
+	s.rw.Unlock()
+}
+
+// Size returns the number of items
+func (s *SyncSet[T]) Size() int {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return len(s.set)
+}
+
+// Slice returns the items as []T
+func (s *SyncSet[T]) Slice() []T {
+	s.rw.RLock()
+	defer s.rw.RUnlock()
+	return s.set.Slice()
+}
+
+// Clone returns a shallow clone of the SyncSet
+func (s *SyncSet[T]) Clone() *SyncSet[T] {
+	if s == nil {
+		return nil
+	}
+	ss := NewSyncSet[T]()
+	s.rw.RLock()
+	for v := range s.set {
+		ss.set[v] = struct{}{}
+	}
+	s.rw.RUnlock()
+	return ss
+}
+
+// Each calls a function, once for every value, inside the mutex lock state
+func (s *SyncSet[T]) Each(f func(v T)) {
+	s.rw.RLock()
+	s.set.Each(f)
+	s.rw.RUnlock()
+}
+
+// Delete deletes items
+func (s *SyncSet[T]) Delete(items ...T) {
+	s.rw.Lock()
+	s.set.Delete(items...)
+	s.rw.Unlock()
+}
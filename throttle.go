@@ -0,0 +1,147 @@
+package maps
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// Throttle is an Observer which limits the rate of changes delivered for each key, with a token bucket
+//
+// Changes to a key which exceed the limit are coalesced, and the merged change is delivered to the next Observer when
+// the limit allows, from a timer goroutine
+type Throttle[K comparable, V any] struct {
+	next  Observer[K, V]
+	rate  float64
+	burst float64
+	mu    sync.Mutex
+	keys  map[K]*throttled[K, V]
+	swept time.Time
+}
+
+type throttled[K comparable, V any] struct {
+	tokens  float64
+	last    time.Time
+	pending *Event[K, V]
+	timer   *time.Timer
+}
+
+// NewThrottle creates a *Throttle[K, V] which delivers to next, at most rate changes per second for each key, with
+// up to burst changes at once
+//
+// NewThrottle panics if rate is not positive and finite, or burst is less than 1
+func NewThrottle[K comparable, V any](rate float64, burst int, next Observer[K, V]) *Throttle[K, V] {
+	if !(rate > 0) || math.IsInf(rate, 1) {
+		panic("maps: Throttle rate must be positive and finite")
+	} else if burst < 1 {
+		panic("maps: Throttle burst must be at least 1")
+	}
+	return &Throttle[K, V]{
+		next:  next,
+		rate:  rate,
+		burst: float64(burst),
+		keys:  make(map[K]*throttled[K, V]),
+	}
+}
+
+// Observe implements Observer
+func (t *Throttle[K, V]) Observe(id K, new, old V) {
+	observeSingle(t, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (t *Throttle[K, V]) ObserveBatch(events []Event[K, V]) {
+	now := time.Now()
+	ready := make([]Event[K, V], 0, len(events))
+	t.mu.Lock()
+	t.evict(now)
+	for _, e := range events {
+		k := t.keys[e.Key]
+		if k == nil {
+			k = &throttled[K, V]{tokens: t.burst, last: now}
+			t.keys[e.Key] = k
+		}
+		if k.pending != nil {
+			*k.pending = coalesce(*k.pending, e)
+			continue
+		}
+		t.refill(k, now)
+		if k.tokens >= 1 {
+			k.tokens--
+			ready = append(ready, e)
+			continue
+		}
+		key, pending := e.Key, e
+		k.pending = &pending
+		wait := time.Duration((1 - k.tokens) / t.rate * float64(time.Second))
+		k.timer = time.AfterFunc(wait, func() { t.fire(key) })
+	}
+	t.mu.Unlock()
+	if len(ready) > 0 {
+		notify(t.next, ready)
+	}
+}
+
+func (t *Throttle[K, V]) refill(k *throttled[K, V], now time.Time) {
+	k.tokens += now.Sub(k.last).Seconds() * t.rate
+	if k.tokens > t.burst {
+		k.tokens = t.burst
+	}
+	k.last = now
+}
+
+// evict removes the keys with no pending change whose bucket has refilled, which are the same as new keys, at most
+// once in the time to refill a bucket
+func (t *Throttle[K, V]) evict(now time.Time) {
+	if now.Sub(t.swept).Seconds()*t.rate < t.burst {
+		return
+	}
+	t.swept = now
+	for key, k := range t.keys {
+		if k.pending == nil && k.tokens+now.Sub(k.last).Seconds()*t.rate >= t.burst {
+			delete(t.keys, key)
+		}
+	}
+}
+
+func (t *Throttle[K, V]) fire(key K) {
+	t.mu.Lock()
+	k := t.keys[key]
+	if k == nil || k.pending == nil {
+		t.mu.Unlock()
+		return
+	}
+	t.refill(k, time.Now())
+	k.tokens--
+	e := *k.pending
+	k.pending, k.timer = nil, nil
+	t.mu.Unlock()
+	if e.Kind != 0 {
+		notify(t.next, []Event[K, V]{e})
+	}
+}
+
+// Pending returns the number of keys waiting to be delivered
+func (t *Throttle[K, V]) Pending() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := 0
+	for _, k := range t.keys {
+		if k.pending != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// Stop discards every pending change
+func (t *Throttle[K, V]) Stop() {
+	t.mu.Lock()
+	for key, k := range t.keys {
+		if k.timer != nil {
+			k.timer.Stop()
+		}
+		delete(t.keys, key)
+	}
+	t.mu.Unlock()
+}
@@ -0,0 +1,38 @@
+package maps
+
+import (
+	"testing"
+	"time"
+)
+
+func TestThrottleEvictsIdleKeys(t *testing.T) {
+	r := NewRecorder[string, int]()
+	th := NewThrottle[string, int](1000, 1, r)
+	defer th.Stop()
+	th.ObserveBatch([]Event[string, int]{{Kind: EventCreate, Key: "a", New: 1}})
+	time.Sleep(5 * time.Millisecond)
+	th.ObserveBatch([]Event[string, int]{{Kind: EventCreate, Key: "b", New: 1}})
+
+	th.mu.Lock()
+	_, ok := th.keys["a"]
+	th.mu.Unlock()
+	if ok {
+		t.Fatal("idle key was kept")
+	}
+	if r.Len() != 2 {
+		t.Fatalf("events = %v", r.Events())
+	}
+}
+
+func TestNewThrottleRejectsInvalidRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("rate %v did not panic", rate)
+				}
+			}()
+			NewThrottle[string, int](rate, 1, NewRecorder[string, int]())
+		}()
+	}
+}
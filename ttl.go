@@ -0,0 +1,120 @@
+package maps
+
+import "time"
+
+// expiry is a scheduled EventExpire
+type expiry struct {
+	at    time.Time
+	timer *time.Timer
+}
+
+// SetWithTTL changes the value for a key, which is removed after ttl with an EventExpire, unless the key is written
+// again first
+//
+// The deadline is read from the clock set by SetClock. An EventExpire is not a step for Undo and Redo
+//
+// the key is kept, without a TTL, if a CheckedObserver rejects the EventExpire
+//
+// returns the error from the first BeforeSetFunc or CheckedObserver to reject the change
+func (o *Observable[K, V]) SetWithTTL(key K, val V, ttl time.Duration) error {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if err := o.set(key, val); err != nil {
+		return err
+	}
+	if o.ttl == nil {
+		o.ttl = make(map[K]*expiry)
+	}
+	x := &expiry{at: o.now().Add(ttl)}
+	x.timer = time.AfterFunc(ttl, func() { o.expireTimer(key, x) })
+	o.ttl[key] = x
+	return nil
+}
+
+// TTL returns the time remaining before a key expires, if it was set with SetWithTTL
+func (o *Observable[K, V]) TTL(key K) (time.Duration, bool) {
+	o.sync.rw.RLock()
+	defer o.sync.rw.RUnlock()
+	if x := o.ttl[key]; x != nil {
+		return x.at.Sub(o.now()), true
+	}
+	return 0, false
+}
+
+// ExpireDue removes every key whose deadline has passed by the clock set by SetClock, and returns the number of keys
+// removed
+//
+// Keys are also removed by timers, so ExpireDue is only needed when the clock does not follow time.Now
+func (o *Observable[K, V]) ExpireDue() int {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	n, now := 0, o.now()
+	for key, x := range o.ttl {
+		if !now.Before(x.at) {
+			x.timer.Stop()
+			if o.expire(key) {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+// expireTimer is called by the timer for x, which is reset if the clock has not reached the deadline
+func (o *Observable[K, V]) expireTimer(key K, x *expiry) {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if o.ttl[key] != x {
+		return
+	} else if d := x.at.Sub(o.now()); d > 0 {
+		x.timer.Reset(d)
+		return
+	}
+	o.expire(key)
+}
+
+// expire removes a key with an EventExpire, unless a CheckedObserver rejects it, and returns whether it was removed
+func (o *Observable[K, V]) expire(key K) bool {
+	delete(o.ttl, key)
+	e := Event[K, V]{Kind: EventExpire, Key: key, Old: o.sync.data[key]}
+	if o.prepare(e) != nil {
+		return false
+	}
+	o.undoing = true // reset by unlock
+	delete(o.sync.data, key)
+	o.emit(e)
+	return true
+}
+
+// stoppedTTL is an expiry stopped by the queued change at index i, which is restarted if the change is aborted
+type stoppedTTL[K comparable] struct {
+	i   int
+	key K
+	x   *expiry
+}
+
+func (o *Observable[K, V]) stopTTL(key K) {
+	if x := o.dropTTL(key); x != nil {
+		o.stopped = append(o.stopped, stoppedTTL[K]{i: len(o.batch), key: key, x: x})
+	}
+}
+
+// dropTTL stops the expiry for a key, and returns it, if there is one
+func (o *Observable[K, V]) dropTTL(key K) *expiry {
+	x := o.ttl[key]
+	if x != nil {
+		x.timer.Stop()
+		delete(o.ttl, key)
+	}
+	return x
+}
+
+// restoreTTL restarts the expiries stopped by the queued changes after mark
+func (o *Observable[K, V]) restoreTTL(mark int) {
+	for len(o.stopped) > 0 && o.stopped[len(o.stopped)-1].i >= mark {
+		s := o.stopped[len(o.stopped)-1]
+		o.stopped = o.stopped[:len(o.stopped)-1]
+		o.ttl[s.key] = s.x
+		s.x.timer.Reset(s.x.at.Sub(o.now()))
+	}
+}
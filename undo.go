@@ -0,0 +1,79 @@
+package maps
+
+// undoStack is the changes made by each write, for Undo and Redo
+type undoStack[K comparable, V any] struct {
+	limit        int
+	done, undone [][]Event[K, V]
+}
+
+func (u *undoStack[K, V]) push(events []Event[K, V]) {
+	u.done = append(u.done, events)
+	if len(u.done) > u.limit {
+		u.done = u.done[len(u.done)-u.limit:]
+	}
+	u.undone = nil
+}
+
+// EnableUndo keeps the changes made by up to limit writes, for Undo and Redo
+//
+// Each call to a write method, such as Set, Delete, or Txn, is a single step. EnableUndo panics if limit is negative
+func (o *Observable[K, V]) EnableUndo(limit int) {
+	if limit < 0 {
+		panic("maps: undo limit must not be negative")
+	}
+	o.sync.rw.Lock()
+	o.undo = &undoStack[K, V]{limit: limit}
+	o.sync.rw.Unlock()
+}
+
+// Undo reverts up to n steps, and returns the number of steps reverted
+//
+// Reverted changes notify observers, and do not call BeforeSet hooks or CheckedObservers
+func (o *Observable[K, V]) Undo(n int) int {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if o.undo == nil {
+		return 0
+	}
+	o.undoing = true // reset by unlock
+	i := 0
+	for ; i < n && len(o.undo.done) > 0; i++ {
+		step := o.undo.done[len(o.undo.done)-1]
+		o.undo.done = o.undo.done[:len(o.undo.done)-1]
+		for j := len(step) - 1; j >= 0; j-- {
+			if e := step[j]; e.Kind == EventCreate {
+				o.remove(e.Key)
+			} else {
+				o.put(e.Key, e.Old)
+			}
+		}
+		o.undo.undone = append(o.undo.undone, step)
+	}
+	return i
+}
+
+// Redo reapplies up to n steps reverted by Undo, and returns the number of steps reapplied
+//
+// Any other write discards the steps available to Redo
+func (o *Observable[K, V]) Redo(n int) int {
+	o.sync.rw.Lock()
+	defer o.unlock()
+	if o.undo == nil {
+		return 0
+	}
+	o.undoing = true // reset by unlock
+	i := 0
+	for ; i < n && len(o.undo.undone) > 0; i++ {
+		step := o.undo.undone[len(o.undo.undone)-1]
+		o.undo.undone = o.undo.undone[:len(o.undo.undone)-1]
+		for _, e := range step {
+			if e.Kind.IsDelete() {
+				o.remove(e.Key)
+			} else {
+				o.put(e.Key, e.New)
+			}
+		}
+		o.undo.done = append(o.undo.done, step)
+	}
+	return i
+}
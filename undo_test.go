@@ -0,0 +1,37 @@
+package maps
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUndoRedoWithTTL(t *testing.T) {
+	o := NewObservable[string, int]()
+	o.EnableUndo(2)
+
+	o.SetWithTTL("a", 1, time.Hour)
+	o.Set("a", 2)
+	o.Set("b", 3)
+	if n := o.Undo(5); n != 2 || o.Get("a") != 1 || o.Size() != 1 {
+		t.Fatalf("Undo = %d, keys = %v", n, o.Keys())
+	}
+	if n := o.Redo(1); n != 1 || o.Get("a") != 2 {
+		t.Fatalf("Redo = %d, a = %d", n, o.Get("a"))
+	}
+	if _, ok := o.TTL("a"); ok {
+		t.Fatal("a kept the TTL of the value it replaced")
+	}
+	o.Set("c", 4)
+	if n := o.Redo(1); n != 0 {
+		t.Fatalf("Redo = %d after another write", n)
+	}
+}
+
+func TestEnableUndoRejectsNegativeLimit(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("EnableUndo(-1) did not panic")
+		}
+	}()
+	NewObservable[string, int]().EnableUndo(-1)
+}
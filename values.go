@@ -0,0 +1,62 @@
+package maps
+
+import (
+	"net/textproto"
+	"net/url"
+	"strings"
+)
+
+// JoinValues returns a map with the result of join for every slice of values, such as url.Values or http.Header
+//
+// Keys with no values are not kept
+func JoinValues[M ~map[K][]V, K comparable, V any](m M, join func([]V) V) map[K]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K]V, len(m))
+	for k, vs := range m {
+		if len(vs) > 0 {
+			r[k] = join(vs)
+		}
+	}
+	return r
+}
+
+// SplitValues returns a map with a slice of 1 value for every value, the inverse of JoinValues
+func SplitValues[M ~map[K]V, K comparable, V any](m M) map[K][]V {
+	if m == nil {
+		return nil
+	}
+	r := make(map[K][]V, len(m))
+	for k, v := range m {
+		r[k] = []V{v}
+	}
+	return r
+}
+
+// FirstValue is a join func for JoinValues which keeps the first value
+func FirstValue[V any](vs []V) V { return vs[0] }
+
+// LastValue is a join func for JoinValues which keeps the last value
+func LastValue[V any](vs []V) V { return vs[len(vs)-1] }
+
+// JoinStrings returns a join func for JoinValues which joins values with sep
+func JoinStrings(sep string) func([]string) string {
+	return func(vs []string) string { return strings.Join(vs, sep) }
+}
+
+// ValuesFromMap returns url.Values with a single value for every key
+func ValuesFromMap[M ~map[string]string](m M) url.Values {
+	return url.Values(SplitValues(m))
+}
+
+// MIMEHeaderFromMap returns a textproto.MIMEHeader with a single value for every key, in canonical form
+//
+// The result converts to http.Header, which has the same underlying type
+func MIMEHeaderFromMap[M ~map[string]string](m M) textproto.MIMEHeader {
+	h := make(textproto.MIMEHeader, len(m))
+	for k, v := range m {
+		h.Add(k, v)
+	}
+	return h
+}
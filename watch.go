@@ -0,0 +1,47 @@
+package maps
+
+import "context"
+
+// OnSet returns a channel which receives every EventCreate and EventUpdate, until ctx is done
+//
+// Events are queued without blocking writers, and the channel is closed when ctx is done
+func (o *Observable[K, V]) OnSet(ctx context.Context) <-chan Event[K, V] {
+	return o.watch(ctx, func(kind EventKind) bool { return !kind.IsDelete() })
+}
+
+// OnDelete returns a channel which receives every EventDelete and EventExpire, until ctx is done
+//
+// Events are queued without blocking writers, and the channel is closed when ctx is done
+func (o *Observable[K, V]) OnDelete(ctx context.Context) <-chan Event[K, V] {
+	return o.watch(ctx, EventKind.IsDelete)
+}
+
+func (o *Observable[K, V]) watch(ctx context.Context, test func(EventKind) bool) <-chan Event[K, V] {
+	ch, q := make(chan Event[K, V]), newQueue[Event[K, V]]()
+	cancel := o.Subscribe(BatchObserverFunc[K, V](func(events []Event[K, V]) {
+		for _, e := range events {
+			if test(e.Kind) {
+				q.push(e)
+			}
+		}
+	}))
+	go func() {
+		defer close(ch)
+		defer cancel()
+		for {
+			for _, e := range q.take() {
+				select {
+				case ch <- e:
+				case <-ctx.Done():
+					return
+				}
+			}
+			select {
+			case <-q.signal:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
@@ -0,0 +1,85 @@
+package maps
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// WriterObserver is an Observer which writes every Event to an io.Writer as newline-delimited JSON
+//
+// Output is buffered until Flush or Close
+type WriterObserver[K comparable, V any] struct {
+	mu  sync.Mutex
+	w   io.Writer
+	buf *bufio.Writer
+	enc *json.Encoder
+	err error
+}
+
+type writerRecord[K comparable, V any] struct {
+	Key  K         `json:"key"`
+	Kind EventKind `json:"kind"`
+	Old  *V        `json:"old,omitempty"`
+	New  *V        `json:"new,omitempty"`
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"ts"`
+}
+
+// NewWriterObserver creates a *WriterObserver[K, V] which writes to w
+func NewWriterObserver[K comparable, V any](w io.Writer) *WriterObserver[K, V] {
+	buf := bufio.NewWriter(w)
+	return &WriterObserver[K, V]{w: w, buf: buf, enc: json.NewEncoder(buf)}
+}
+
+// Observe implements Observer
+func (w *WriterObserver[K, V]) Observe(id K, new, old V) {
+	observeSingle(w, id, new, old)
+}
+
+// ObserveBatch implements BatchObserver
+func (w *WriterObserver[K, V]) ObserveBatch(events []Event[K, V]) {
+	w.mu.Lock()
+	for i := 0; i < len(events) && w.err == nil; i++ {
+		e := events[i]
+		r := writerRecord[K, V]{Key: e.Key, Kind: e.Kind, Seq: e.Seq, Time: e.Time}
+		if e.Kind != EventCreate {
+			r.Old = &e.Old
+		}
+		if !e.Kind.IsDelete() {
+			r.New = &e.New
+		}
+		w.err = w.enc.Encode(r)
+	}
+	w.mu.Unlock()
+}
+
+// Flush writes any buffered output
+func (w *WriterObserver[K, V]) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err == nil {
+		w.err = w.buf.Flush()
+	}
+	return w.err
+}
+
+// Close flushes, and closes the io.Writer if it is an io.Closer
+func (w *WriterObserver[K, V]) Close() error {
+	err := w.Flush()
+	if c, ok := w.w.(io.Closer); ok {
+		if cerr := c.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// Err returns the first error from writing, after which no more events are written
+func (w *WriterObserver[K, V]) Err() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.err
+}